@@ -0,0 +1,108 @@
+// Package drain defines a shutdown handler draining traffic before
+// shutting down a root handler, as needed for Kubernetes pods.
+package drain
+
+import (
+	"context"
+	"time"
+
+	"github.com/qdm12/goshutdown/handler"
+	"github.com/qdm12/goshutdown/report"
+)
+
+//go:generate mockgen -destination=mock_$GOPACKAGE/$GOFILE . Handler
+
+// Handler handles the draining phase of the shutdown, before
+// shutting down the root handler.
+// The handler created by New also implements handler.Parent.
+type Handler interface {
+	// Name returns the name set for this drain handler.
+	Name() string
+	// IsCritical returns true if the drain handler is critical and must be
+	// terminated before continuing other external shutdown procedures.
+	IsCritical() bool
+	// Shutdown sets the readiness state to draining, waits for the pre-stop
+	// delay or for the shutdown context to be done, and then shuts down the
+	// root handler with the shutdown context, returning its error.
+	Shutdown(ctx context.Context) (err error)
+	// ShutdownReport shuts down as Shutdown does, and returns the
+	// shutdown report with the report of the root handler as child.
+	ShutdownReport(ctx context.Context) (report *report.Report, err error)
+}
+
+// New creates a drain handler for the root handler and readiness state given.
+func New(name string, root handler.Handler, readiness *Readiness,
+	options ...Option) Handler {
+	settings := newSettings()
+	for _, option := range options {
+		option(&settings)
+	}
+
+	return &drainHandler{
+		name:      name,
+		settings:  settings,
+		root:      root,
+		readiness: readiness,
+	}
+}
+
+type drainHandler struct {
+	name      string
+	settings  settings
+	root      handler.Handler
+	readiness *Readiness
+}
+
+func (h *drainHandler) Name() string {
+	return h.name
+}
+
+func (h *drainHandler) IsCritical() bool {
+	return h.settings.critical
+}
+
+// Children returns the root handler.
+func (h *drainHandler) Children() []handler.Handler {
+	return []handler.Handler{h.root}
+}
+
+func (h *drainHandler) Shutdown(ctx context.Context) (err error) {
+	_, err = h.ShutdownReport(ctx)
+	return err
+}
+
+func (h *drainHandler) ShutdownReport(ctx context.Context) (
+	rep *report.Report, err error) {
+	start := time.Now()
+	rep = &report.Report{
+		Name:     h.name,
+		Kind:     report.KindHandler,
+		Critical: h.settings.critical,
+	}
+	defer func() {
+		rep.Duration = time.Since(start)
+		rep.SetError(err)
+	}()
+
+	h.readiness.SetDraining()
+	h.waitPreStopDelay(ctx)
+
+	rootReport, err := report.Shutdown(ctx, h.root, h.root.Name(), h.root.IsCritical())
+	rep.Children = []*report.Report{rootReport}
+	return rep, err
+}
+
+// waitPreStopDelay waits for the pre-stop delay to elapse,
+// or for the context to be done.
+func (h *drainHandler) waitPreStopDelay(ctx context.Context) {
+	if h.settings.preStopDelay == 0 {
+		return
+	}
+
+	timer := time.NewTimer(h.settings.preStopDelay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+}
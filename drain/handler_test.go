@@ -0,0 +1,107 @@
+package drain
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/qdm12/goshutdown/handler/mock_handler"
+	"github.com/qdm12/goshutdown/report"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_New(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	root := mock_handler.NewMockHandler(ctrl)
+	readiness := NewReadiness()
+
+	intf := New("name", root, readiness,
+		OptionPreStopDelay(time.Hour), OptionCritical())
+
+	impl, ok := intf.(*drainHandler)
+	require.True(t, ok)
+	expected := &drainHandler{
+		name: "name",
+		settings: settings{
+			preStopDelay: time.Hour,
+			critical:     true,
+		},
+		root:      root,
+		readiness: readiness,
+	}
+	assert.Equal(t, expected, impl)
+	assert.Equal(t, "name", intf.Name())
+	assert.True(t, intf.IsCritical())
+}
+
+func Test_drainHandler_Shutdown(t *testing.T) {
+	t.Parallel()
+
+	errTest := errors.New("test error")
+
+	t.Run("drains before shutting down root", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+
+		readiness := NewReadiness()
+		readiness.SetReady()
+		server := httptest.NewServer(readiness)
+		t.Cleanup(server.Close)
+
+		const preStopDelay = 50 * time.Millisecond
+		var preStopElapsed time.Duration
+		start := time.Now()
+
+		root := mock_handler.NewMockHandler(ctrl)
+		root.EXPECT().Name().Return("root")
+		root.EXPECT().IsCritical().Return(false)
+		root.EXPECT().Shutdown(gomock.Any()).DoAndReturn(
+			func(ctx context.Context) error {
+				preStopElapsed = time.Since(start)
+				response, err := http.Get(server.URL) //nolint:noctx
+				require.NoError(t, err)
+				_ = response.Body.Close()
+				assert.Equal(t, http.StatusServiceUnavailable, response.StatusCode)
+				return errTest
+			})
+
+		h := New("drain", root, readiness, OptionPreStopDelay(preStopDelay))
+
+		rep, err := h.ShutdownReport(context.Background())
+
+		assert.Equal(t, errTest, err)
+		assert.GreaterOrEqual(t, preStopElapsed, preStopDelay)
+		assert.Equal(t, StateDraining, readiness.State())
+		assert.Equal(t, "drain", rep.Name)
+		assert.Equal(t, report.StatusFailure, rep.Status)
+		require.Len(t, rep.Children, 1)
+		assert.Equal(t, "root", rep.Children[0].Name)
+		assert.Equal(t, "test error", rep.Children[0].Error)
+	})
+
+	t.Run("context done during pre-stop delay", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		root := mock_handler.NewMockHandler(ctrl)
+		root.EXPECT().Name().Return("root")
+		root.EXPECT().IsCritical().Return(false)
+		root.EXPECT().Shutdown(ctx).Return(nil)
+
+		h := New("drain", root, NewReadiness(), OptionPreStopDelay(time.Hour))
+
+		err := h.Shutdown(ctx)
+
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,21 @@
+package drain
+
+import "time"
+
+type Option func(s *settings)
+
+// OptionPreStopDelay sets the delay to wait after setting the readiness
+// state to draining, before shutting down the root handler.
+// Note the delay defaults to five seconds, and can be set to 0 to disable it.
+func OptionPreStopDelay(delay time.Duration) Option {
+	return func(s *settings) {
+		s.preStopDelay = delay
+	}
+}
+
+// OptionCritical marks the shutdown operation as critical.
+func OptionCritical() Option {
+	return func(s *settings) {
+		s.critical = true
+	}
+}
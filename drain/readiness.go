@@ -0,0 +1,81 @@
+package drain
+
+import (
+	"net/http"
+	"sync"
+)
+
+// State is the readiness state of the program.
+type State uint8
+
+const (
+	// StateNotReady is the state of the program before it is ready.
+	StateNotReady State = iota
+	// StateReady is the state of the program ready to receive traffic.
+	StateReady
+	// StateDraining is the state of the program shutting down,
+	// which should no longer receive new traffic.
+	StateDraining
+)
+
+func (s State) String() string {
+	switch s {
+	case StateNotReady:
+		return "not ready"
+	case StateReady:
+		return "ready"
+	case StateDraining:
+		return "draining"
+	default:
+		return "unknown"
+	}
+}
+
+// Readiness is the readiness state of the program, starting as
+// StateNotReady. It is an http.Handler to serve on a readiness probe
+// path such as /readyz, responding with 200 if the state is StateReady,
+// and 503 otherwise. It is safe for concurrent use.
+type Readiness struct {
+	state State
+	mutex sync.RWMutex
+}
+
+// NewReadiness creates a readiness state not ready.
+func NewReadiness() *Readiness {
+	return &Readiness{}
+}
+
+// SetReady sets the state to StateReady, unless the state is
+// already StateDraining since draining cannot be undone.
+func (r *Readiness) SetReady() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.state != StateDraining {
+		r.state = StateReady
+	}
+}
+
+// SetDraining sets the state to StateDraining.
+func (r *Readiness) SetDraining() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.state = StateDraining
+}
+
+// State returns the current readiness state.
+func (r *Readiness) State() State {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	return r.state
+}
+
+func (r *Readiness) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	state := r.State()
+	status := http.StatusOK
+	if state != StateReady {
+		status = http.StatusServiceUnavailable
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(status)
+	_, _ = w.Write([]byte(state.String() + "\n"))
+}
@@ -0,0 +1,56 @@
+package drain
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_State_String(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "not ready", StateNotReady.String())
+	assert.Equal(t, "ready", StateReady.String())
+	assert.Equal(t, "draining", StateDraining.String())
+	assert.Equal(t, "unknown", State(255).String())
+}
+
+func Test_Readiness(t *testing.T) {
+	t.Parallel()
+
+	readiness := NewReadiness()
+	server := httptest.NewServer(readiness)
+	t.Cleanup(server.Close)
+
+	get := func() (status int, body string) {
+		t.Helper()
+		response, err := http.Get(server.URL + "/readyz") //nolint:noctx
+		require.NoError(t, err)
+		defer response.Body.Close()
+		b, err := io.ReadAll(response.Body)
+		require.NoError(t, err)
+		return response.StatusCode, string(b)
+	}
+
+	status, body := get()
+	assert.Equal(t, http.StatusServiceUnavailable, status)
+	assert.Equal(t, "not ready\n", body)
+
+	readiness.SetReady()
+	status, body = get()
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "ready\n", body)
+
+	readiness.SetDraining()
+	status, body = get()
+	assert.Equal(t, http.StatusServiceUnavailable, status)
+	assert.Equal(t, "draining\n", body)
+
+	// draining cannot be undone
+	readiness.SetReady()
+	assert.Equal(t, StateDraining, readiness.State())
+}
@@ -0,0 +1,21 @@
+package drain
+
+import "time"
+
+// settings defines configuration settings for the drain handler.
+type settings struct {
+	// preStopDelay is the delay to wait after setting the readiness state
+	// to draining, before shutting down the root handler. It gives time to
+	// load balancers, such as Kubernetes endpoints, to stop sending traffic.
+	// It defaults to 5s if left unset.
+	preStopDelay time.Duration
+	// critical can be set to true to indicate the shutdown process should exit if
+	// the root handler cannot be shut down.
+	critical bool
+}
+
+func newSettings() settings {
+	return settings{
+		preStopDelay: 5 * time.Second,
+	}
+}
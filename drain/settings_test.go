@@ -0,0 +1,19 @@
+package drain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_newSettings(t *testing.T) {
+	t.Parallel()
+
+	s := newSettings()
+
+	expected := settings{
+		preStopDelay: 5 * time.Second,
+	}
+	assert.Equal(t, expected, s)
+}
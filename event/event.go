@@ -0,0 +1,86 @@
+// Package event defines the events emitted during the shutdown of a tree
+// of handlers, and the observer interface to receive them.
+package event
+
+import (
+	"time"
+
+	"github.com/qdm12/goshutdown/report"
+)
+
+// Type is the type of an event.
+type Type string
+
+const (
+	// TypeShutdownStarted is the type of the event emitted when the
+	// shutdown of an order, group or graph handler starts.
+	TypeShutdownStarted Type = "shutdown_started"
+	// TypeHandlerStarted is the type of the event emitted when the
+	// shutdown of a child handler or a hook starts.
+	TypeHandlerStarted Type = "handler_started"
+	// TypeHandlerSucceeded is the type of the event emitted when
+	// a child handler or a hook terminates successfully.
+	TypeHandlerSucceeded Type = "handler_succeeded"
+	// TypeHandlerFailed is the type of the event emitted when
+	// a child handler or a hook fails to terminate.
+	TypeHandlerFailed Type = "handler_failed"
+	// TypeHandlerSkipped is the type of the event emitted when a child
+	// handler is not shut down because of a critical failure.
+	TypeHandlerSkipped Type = "handler_skipped"
+	// TypeCriticalAbort is the type of the event emitted when a critical
+	// child handler fails, aborting the shutdown of the other children.
+	TypeCriticalAbort Type = "critical_abort"
+	// TypeShutdownFinished is the type of the event emitted when the
+	// shutdown of an order, group or graph handler finishes.
+	TypeShutdownFinished Type = "shutdown_finished"
+)
+
+// Event is an event emitted during the shutdown.
+type Event struct {
+	// Type is the type of the event.
+	Type Type
+	// Time is the time the event occurred at.
+	Time time.Time
+	// Path is the path of the handler in the tree of handlers,
+	// made of the handler names separated by slashes, for example
+	// "order/group/worker". For TypeCriticalAbort, it is the path
+	// of the critical handler which failed.
+	Path string
+	// Name is the name of the handler, which is the last element of Path.
+	Name string
+	// ID identifies the shutdown of the handler, and is unique even for
+	// handlers with the same path. The events of a nested order, group
+	// or graph handler have the same ID as the events emitted for it by
+	// its parent. For TypeCriticalAbort, it is the ID of the critical
+	// handler which failed.
+	ID uint64
+	// ParentID is the ID of the parent handler, and is 0 for the root handler.
+	ParentID uint64
+	// Critical is true if the handler is critical.
+	Critical bool
+	// Duration is the time taken to shutdown the handler, and is
+	// only set for TypeHandlerSucceeded, TypeHandlerFailed and
+	// TypeShutdownFinished events.
+	Duration time.Duration
+	// Status is the shutdown status of the handler, and is only set for
+	// TypeHandlerSucceeded, TypeHandlerFailed and TypeShutdownFinished events.
+	Status report.Status
+	// Err is the shutdown error of the handler, and is only set for
+	// TypeHandlerFailed, TypeCriticalAbort and TypeShutdownFinished events.
+	Err error
+}
+
+// Observer observes the events emitted during the shutdown.
+// It must be safe for concurrent use, since handlers of a group
+// are shut down in parallel.
+type Observer interface {
+	Observe(event Event)
+}
+
+// ObserverFunc is a function implementing the Observer interface.
+type ObserverFunc func(event Event)
+
+// Observe calls the function with the event given.
+func (f ObserverFunc) Observe(event Event) {
+	f(event)
+}
@@ -0,0 +1,193 @@
+package event
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/qdm12/goshutdown/report"
+)
+
+type contextKey struct{}
+
+// contextValue is the value stored in a context given to children
+// handlers, to propagate the observers, the path and the ID of the parent.
+type contextValue struct {
+	observers []Observer
+	path      string
+	parentID  uint64
+	// id is the ID of the child handler given the context,
+	// and is 0 if the context is shared by the children.
+	id uint64
+}
+
+// lastID is the last ID given to a handler shutdown.
+var lastID uint64 //nolint:gochecknoglobals
+
+func newID() uint64 {
+	return atomic.AddUint64(&lastID, 1)
+}
+
+// Scope emits the events of an handler having children handlers,
+// such as an order or group handler.
+type Scope struct {
+	observers []Observer
+	path      string
+	name      string
+	critical  bool
+	id        uint64
+	parentID  uint64
+}
+
+// NewScope returns the events scope of the handler with the name given,
+// and the context to give to its children handlers. The scope observers
+// are the observers of the parent scope found in the context, and the
+// observers given. The path of the handler is the path of the parent scope
+// joined with the name, or the name if there is no parent scope. The ID of
+// the handler is the ID given by the parent scope in the context if any.
+func NewScope(ctx context.Context, name string, critical bool,
+	observers ...Observer) (childrenCtx context.Context, scope *Scope) {
+	parent, _ := ctx.Value(contextKey{}).(contextValue)
+
+	path := name
+	if parent.path != "" {
+		path = parent.path + "/" + name
+	}
+
+	allObservers := make([]Observer, 0, len(parent.observers)+len(observers))
+	allObservers = append(allObservers, parent.observers...)
+	allObservers = append(allObservers, observers...)
+
+	id := parent.id
+	if id == 0 {
+		id = newID()
+	}
+
+	scope = &Scope{
+		observers: allObservers,
+		path:      path,
+		name:      name,
+		critical:  critical,
+		id:        id,
+		parentID:  parent.parentID,
+	}
+
+	childrenCtx = context.WithValue(ctx, contextKey{}, contextValue{
+		observers: allObservers,
+		path:      path,
+		parentID:  id,
+	})
+	return childrenCtx, scope
+}
+
+func (s *Scope) emit(event Event) {
+	if len(s.observers) == 0 {
+		return
+	}
+	event.Time = time.Now()
+	for _, observer := range s.observers {
+		observer.Observe(event)
+	}
+}
+
+func (s *Scope) childPath(name string) string {
+	return s.path + "/" + name
+}
+
+// ShutdownStarted emits a TypeShutdownStarted event for the scope handler.
+func (s *Scope) ShutdownStarted() {
+	s.emit(Event{
+		Type:     TypeShutdownStarted,
+		Path:     s.path,
+		Name:     s.name,
+		ID:       s.id,
+		ParentID: s.parentID,
+		Critical: s.critical,
+	})
+}
+
+// ShutdownFinished emits a TypeShutdownFinished event for the scope
+// handler, using its shutdown report and error given.
+func (s *Scope) ShutdownFinished(rep *report.Report, err error) {
+	s.emit(Event{
+		Type:     TypeShutdownFinished,
+		Path:     s.path,
+		Name:     s.name,
+		ID:       s.id,
+		ParentID: s.parentID,
+		Critical: s.critical,
+		Duration: rep.Duration,
+		Status:   rep.Status,
+		Err:      err,
+	})
+}
+
+// HandlerStarted emits a TypeHandlerStarted event for the child handler,
+// and returns the ID of the child handler and the context to give to it,
+// derived from the children context given.
+func (s *Scope) HandlerStarted(ctx context.Context, name string, critical bool) (
+	childCtx context.Context, id uint64) {
+	id = newID()
+	s.emit(Event{
+		Type:     TypeHandlerStarted,
+		Path:     s.childPath(name),
+		Name:     name,
+		ID:       id,
+		ParentID: s.id,
+		Critical: critical,
+	})
+	childCtx = context.WithValue(ctx, contextKey{}, contextValue{
+		observers: s.observers,
+		path:      s.path,
+		parentID:  s.id,
+		id:        id,
+	})
+	return childCtx, id
+}
+
+// HandlerFinished emits a TypeHandlerSucceeded event for the child handler
+// with the ID given if the error given is nil, and a TypeHandlerFailed
+// event otherwise.
+func (s *Scope) HandlerFinished(id uint64, childReport *report.Report, err error) {
+	eventType := TypeHandlerSucceeded
+	if err != nil {
+		eventType = TypeHandlerFailed
+	}
+	s.emit(Event{
+		Type:     eventType,
+		Path:     s.childPath(childReport.Name),
+		Name:     childReport.Name,
+		ID:       id,
+		ParentID: s.id,
+		Critical: childReport.Critical,
+		Duration: childReport.Duration,
+		Status:   childReport.Status,
+		Err:      err,
+	})
+}
+
+// HandlerSkipped emits a TypeHandlerSkipped event for the child handler.
+func (s *Scope) HandlerSkipped(name string, critical bool) {
+	s.emit(Event{
+		Type:     TypeHandlerSkipped,
+		Path:     s.childPath(name),
+		Name:     name,
+		ID:       newID(),
+		ParentID: s.id,
+		Critical: critical,
+	})
+}
+
+// CriticalAbort emits a TypeCriticalAbort event for the critical
+// child handler with the ID given which failed.
+func (s *Scope) CriticalAbort(id uint64, name string, err error) {
+	s.emit(Event{
+		Type:     TypeCriticalAbort,
+		Path:     s.childPath(name),
+		Name:     name,
+		ID:       id,
+		ParentID: s.id,
+		Critical: true,
+		Err:      err,
+	})
+}
@@ -0,0 +1,138 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/qdm12/goshutdown/report"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recorder is an observer recording the events it observes.
+type recorder struct {
+	mutex  sync.Mutex
+	events []Event
+}
+
+func (r *recorder) Observe(event Event) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.events = append(r.events, event)
+}
+
+// clearTimes sets the time of the recorded events to the zero time,
+// after checking it is set.
+func (r *recorder) clearTimes(t *testing.T) {
+	t.Helper()
+	for i := range r.events {
+		assert.False(t, r.events[i].Time.IsZero())
+		r.events[i].Time = time.Time{}
+	}
+}
+
+func Test_NewScope(t *testing.T) {
+	t.Parallel()
+
+	parentObserver := new(recorder)
+	childObserver := new(recorder)
+
+	ctx, parent := NewScope(context.Background(), "order", true, parentObserver)
+	require.NotZero(t, parent.id)
+	assert.Equal(t, &Scope{
+		observers: []Observer{parentObserver},
+		path:      "order",
+		name:      "order",
+		critical:  true,
+		id:        parent.id,
+	}, parent)
+
+	_, child := NewScope(ctx, "group", false, childObserver)
+	require.NotZero(t, child.id)
+	assert.NotEqual(t, parent.id, child.id)
+	assert.Equal(t, &Scope{
+		observers: []Observer{parentObserver, childObserver},
+		path:      "order/group",
+		name:      "group",
+		id:        child.id,
+		parentID:  parent.id,
+	}, child)
+
+	// A nested scope uses the ID given by its parent scope
+	childCtx, childID := parent.HandlerStarted(ctx, "group", false)
+	_, child = NewScope(childCtx, "group", false)
+	assert.Equal(t, childID, child.id)
+	assert.Equal(t, parent.id, child.parentID)
+}
+
+func Test_Scope(t *testing.T) {
+	t.Parallel()
+
+	errTest := errors.New("test error")
+	observer := new(recorder)
+
+	ctx, scope := NewScope(context.Background(), "order", false, observer)
+
+	scope.ShutdownStarted()
+	_, idA := scope.HandlerStarted(ctx, "A", true)
+	scope.HandlerFinished(idA, &report.Report{Name: "A", Critical: true,
+		Duration: time.Second, Status: report.StatusFailure}, errTest)
+	scope.CriticalAbort(idA, "A", errTest)
+	scope.HandlerSkipped("B", false)
+	_, idC := scope.HandlerStarted(ctx, "C", false)
+	scope.HandlerFinished(idC, &report.Report{Name: "C", Duration: time.Millisecond,
+		Status: report.StatusSuccess}, nil)
+	scope.ShutdownFinished(&report.Report{Duration: 2 * time.Second,
+		Status: report.StatusFailure}, errTest)
+
+	observer.clearTimes(t)
+	require.Len(t, observer.events, 8)
+	idB := observer.events[4].ID
+	assert.NotZero(t, idB)
+	assert.NotEqual(t, idA, idC)
+	id := scope.id
+	expected := []Event{
+		{Type: TypeShutdownStarted, Path: "order", Name: "order", ID: id},
+		{Type: TypeHandlerStarted, Path: "order/A", Name: "A", ID: idA, ParentID: id,
+			Critical: true},
+		{Type: TypeHandlerFailed, Path: "order/A", Name: "A", ID: idA, ParentID: id,
+			Critical: true, Duration: time.Second, Status: report.StatusFailure, Err: errTest},
+		{Type: TypeCriticalAbort, Path: "order/A", Name: "A", ID: idA, ParentID: id,
+			Critical: true, Err: errTest},
+		{Type: TypeHandlerSkipped, Path: "order/B", Name: "B", ID: idB, ParentID: id},
+		{Type: TypeHandlerStarted, Path: "order/C", Name: "C", ID: idC, ParentID: id},
+		{Type: TypeHandlerSucceeded, Path: "order/C", Name: "C", ID: idC, ParentID: id,
+			Duration: time.Millisecond, Status: report.StatusSuccess},
+		{Type: TypeShutdownFinished, Path: "order", Name: "order", ID: id,
+			Duration: 2 * time.Second, Status: report.StatusFailure, Err: errTest},
+	}
+	assert.Equal(t, expected, observer.events)
+}
+
+func Test_Scope_noObserver(t *testing.T) {
+	t.Parallel()
+
+	ctx, scope := NewScope(context.Background(), "order", false)
+
+	require.NotPanics(t, scope.ShutdownStarted)
+	_, child := NewScope(ctx, "group", false)
+	assert.Equal(t, "order/group", child.path)
+	assert.Empty(t, child.observers)
+}
+
+func Test_ObserverFunc(t *testing.T) {
+	t.Parallel()
+
+	var observed Event
+	var observer Observer = ObserverFunc(func(event Event) {
+		observed = event
+	})
+
+	event := Event{Type: TypeShutdownStarted, Name: "name"}
+	observer.Observe(event)
+
+	assert.Equal(t, event, observed)
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"time"
+
+	"github.com/qdm12/goshutdown/goroutine"
+	"github.com/qdm12/goshutdown/order"
+	"github.com/qdm12/goshutdown/runner"
+)
+
+func main() {
+	order := order.New("order",
+		order.OptionTimeout(time.Second),
+		order.OptionOnSuccess(func(name string) { log.Println(name + " terminated 🙌") }),
+		order.OptionOnFailure(func(name string, err error) { log.Println(name + " did not terminate 😱: " + err.Error()) }),
+	)
+
+	handlerA, ctxA, doneA := goroutine.New("functionA")
+	go functionA(ctxA, doneA)
+	order.Append(handlerA)
+
+	exitCode := runner.Run(order,
+		runner.OptionOnSignal(func(signal os.Signal) { log.Println("caught OS signal " + signal.String()) }),
+		runner.OptionOnShutdownFailure(func(err error) { log.Println(err) }),
+	)
+	os.Exit(exitCode)
+}
+
+func functionA(ctx context.Context, done chan<- struct{}) {
+	defer close(done)
+	<-ctx.Done()
+	log.Println("🔌 exiting on time!")
+}
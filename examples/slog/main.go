@@ -0,0 +1,45 @@
+//go:build go1.21
+// +build go1.21
+
+package main
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/qdm12/goshutdown/goroutine"
+	"github.com/qdm12/goshutdown/group"
+	"github.com/qdm12/goshutdown/logging"
+	"github.com/qdm12/goshutdown/order"
+	"github.com/qdm12/goshutdown/runner"
+)
+
+func main() {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	observer := logging.New(logger)
+
+	order := order.New("order",
+		order.OptionTimeout(time.Second),
+		observer.OrderOption(),
+	)
+
+	workers := group.New("workers")
+	handlerA, ctxA, doneA := goroutine.New("functionA")
+	go functionA(ctxA, doneA)
+	workers.Add(handlerA)
+	order.Append(workers)
+
+	exitCode := runner.Run(order,
+		runner.OptionOnSignal(func(signal os.Signal) {
+			logger.Info("caught OS signal", slog.String("signal", signal.String()))
+		}),
+	)
+	os.Exit(exitCode)
+}
+
+func functionA(ctx context.Context, done chan<- struct{}) {
+	defer close(done)
+	<-ctx.Done()
+}
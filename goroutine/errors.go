@@ -0,0 +1,60 @@
+package goroutine
+
+import "fmt"
+
+// PanicError is the error returned by the handler Shutdown method
+// when its goroutine panicked.
+type PanicError struct {
+	// Value is the value recovered from the panic.
+	Value interface{}
+	// Stack is the stack trace of the goroutine when it panicked.
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("goroutine panicked: %v", e.Value)
+}
+
+// Unwrap returns the panic value if it is an error, and nil otherwise.
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// ExitError is the error returned by the handler Shutdown method
+// when its goroutine exited with an error.
+type ExitError struct {
+	// Err is the error the goroutine exited with.
+	Err error
+}
+
+func (e *ExitError) Error() string {
+	return "goroutine exited with error: " + e.Err.Error()
+}
+
+// Unwrap returns the error the goroutine exited with.
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
+
+// EarlyExitError is the error sent to a channel registered with
+// NotifyEarlyExit when a goroutine terminates before its shutdown.
+type EarlyExitError struct {
+	// Name is the name of the handler of the goroutine.
+	Name string
+	// Err is the error the goroutine terminated with, and can be nil.
+	Err error
+}
+
+func (e *EarlyExitError) Error() string {
+	message := e.Name + " terminated before shutdown"
+	if e.Err != nil {
+		message += ": " + e.Err.Error()
+	}
+	return message
+}
+
+// Unwrap returns the error the goroutine terminated with.
+func (e *EarlyExitError) Unwrap() error {
+	return e.Err
+}
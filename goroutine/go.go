@@ -0,0 +1,36 @@
+package goroutine
+
+import (
+	"context"
+	"runtime/debug"
+)
+
+// Go creates a goroutine handler and launches the function given in a goroutine.
+// The done signal channel is closed automatically when the function returns or panics.
+// If the function panics, the panic is recovered and Shutdown returns a *PanicError.
+// If the function returns an error, Shutdown returns an *ExitError wrapping it.
+func Go(name string, fn func(ctx context.Context) error, options ...Option) Handler {
+	h, ctx, done := newHandler(name, options...)
+	go h.run(ctx, done, fn)
+	return h
+}
+
+// run runs the function given, and closes the done channel once the
+// function returns or panics, after setting the handler error.
+func (h *handler) run(ctx context.Context, done chan<- struct{},
+	fn func(ctx context.Context) error) {
+	defer close(done)
+	defer func() {
+		if r := recover(); r != nil {
+			h.err = &PanicError{
+				Value: r,
+				Stack: debug.Stack(),
+			}
+		}
+	}()
+
+	err := fn(ctx)
+	if err != nil {
+		h.err = &ExitError{Err: err}
+	}
+}
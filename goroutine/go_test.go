@@ -0,0 +1,94 @@
+package goroutine
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Go(t *testing.T) {
+	t.Parallel()
+
+	errTest := errors.New("test error")
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		h := Go("name", func(ctx context.Context) error {
+			<-ctx.Done()
+			return nil
+		}, OptionTimeout(time.Hour))
+
+		err := h.Shutdown(context.Background())
+		assert.NoError(t, err)
+	})
+
+	t.Run("error returned", func(t *testing.T) {
+		t.Parallel()
+
+		h := Go("name", func(ctx context.Context) error {
+			<-ctx.Done()
+			return errTest
+		}, OptionTimeout(time.Hour))
+
+		err := h.Shutdown(context.Background())
+		require.Error(t, err)
+		assert.ErrorIs(t, err, errTest)
+		var exitErr *ExitError
+		require.True(t, errors.As(err, &exitErr))
+		assert.Equal(t, errTest, exitErr.Err)
+		assert.Equal(t, "goroutine exited with error: test error", err.Error())
+	})
+
+	t.Run("panic with error value", func(t *testing.T) {
+		t.Parallel()
+
+		h := Go("name", func(ctx context.Context) error {
+			<-ctx.Done()
+			panic(errTest)
+		}, OptionTimeout(time.Hour))
+
+		err := h.Shutdown(context.Background())
+		require.Error(t, err)
+		assert.ErrorIs(t, err, errTest)
+		var panicErr *PanicError
+		require.True(t, errors.As(err, &panicErr))
+		assert.Equal(t, errTest, panicErr.Value)
+		assert.Contains(t, string(panicErr.Stack), "goroutine")
+		assert.Equal(t, "goroutine panicked: test error", err.Error())
+	})
+
+	t.Run("panic before shutdown", func(t *testing.T) {
+		t.Parallel()
+
+		h := Go("name", func(ctx context.Context) error {
+			panic("oops")
+		}, OptionTimeout(time.Hour))
+
+		err := h.Shutdown(context.Background())
+		require.Error(t, err)
+		var panicErr *PanicError
+		require.True(t, errors.As(err, &panicErr))
+		assert.Equal(t, "oops", panicErr.Value)
+		assert.NoError(t, panicErr.Unwrap())
+		assert.Equal(t, "goroutine panicked: oops", err.Error())
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		t.Parallel()
+
+		block := make(chan struct{})
+		defer close(block)
+		h := Go("name", func(ctx context.Context) error {
+			<-block
+			return nil
+		}, OptionTimeout(time.Nanosecond))
+
+		err := h.Shutdown(context.Background())
+		assert.ErrorIs(t, err, ErrTimeout)
+	})
+}
@@ -7,7 +7,7 @@ import (
 	"time"
 )
 
-//go:generate mockgen -destination=mock_$GOPACKAGE/$GOFILE . Handler
+//go:generate mockgen -destination=mock_$GOPACKAGE/$GOFILE . Handler,TwoPhaseHandler,ReadyHandler
 
 // Handler handles a goroutine shutdown handler.
 type Handler interface {
@@ -24,31 +24,65 @@ type Handler interface {
 }
 
 // New creates a goroutine handler with a timeout if timeout > 0.
+// The handler returned implements handler.EarlyExitNotifier
+// and handler.Timeouter.
 func New(name string, options ...Option) (
 	h Handler, ctx context.Context, done chan<- struct{}) {
+	return newHandler(name, options...)
+}
+
+// NewWithError creates a goroutine handler with a timeout if timeout > 0.
+// It returns the handler as well as the context and error done channel
+// to use in the actual goroutine. The goroutine should send its exit error,
+// which can be nil, on the done channel or close it when it exits.
+// A non nil error sent is then returned by Shutdown wrapped in an *ExitError.
+func NewWithError(name string, options ...Option) (
+	h Handler, ctx context.Context, done chan<- error) {
+	impl, ctx, signalDone := newHandler(name, options...)
+	errDone := make(chan error)
+
+	go func() {
+		err := <-errDone
+		if err != nil {
+			impl.err = &ExitError{Err: err}
+		}
+		close(signalDone)
+	}()
+
+	return impl, ctx, errDone
+}
+
+func newHandler(name string, options ...Option) (
+	h *handler, ctx context.Context, done chan struct{}) {
 	settings := newSettings()
 	for _, option := range options {
 		option(&settings)
 	}
 
 	ctx, cancel := context.WithCancel(context.Background())
-	bidirectionalDone := make(chan struct{})
+	done = make(chan struct{})
 
 	h = &handler{
 		name:     name,
 		settings: settings,
+		ctx:      ctx,
 		cancel:   cancel,
-		done:     bidirectionalDone,
+		done:     done,
 	}
 
-	return h, ctx, bidirectionalDone
+	return h, ctx, done
 }
 
 type handler struct {
 	name     string
 	settings settings
-	cancel   context.CancelFunc
-	done     <-chan struct{}
+	// ctx is the goroutine context, only canceled by Shutdown.
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   <-chan struct{}
+	// err is the error the goroutine exited with, and must
+	// only be set before the done channel is closed.
+	err error
 }
 
 func (h *handler) Name() string {
@@ -59,23 +93,33 @@ func (h *handler) IsCritical() bool {
 	return h.settings.critical
 }
 
+// Timeout returns the timeout of the goroutine, where 0 indicates no timeout.
+func (h *handler) Timeout() time.Duration {
+	return h.settings.timeout
+}
+
 // ErrTimeout is the error when the goroutine shutdown times out.
 var ErrTimeout = errors.New("goroutine shutdown timed out")
 
 func (h *handler) Shutdown(ctx context.Context) (err error) {
+	h.cancel()
+	return h.wait(ctx)
+}
+
+// wait waits for the goroutine to close its done channel, for the
+// shutdown context to be done or for the timeout to be reached.
+func (h *handler) wait(ctx context.Context) (err error) {
 	timer := time.NewTimer(h.settings.timeout)
 	if h.settings.timeout == 0 {
 		timer.Stop()
 	}
 
-	h.cancel()
-
 	select {
 	case <-h.done:
 		if h.settings.timeout > 0 && !timer.Stop() {
 			<-timer.C
 		}
-		return nil
+		return h.err
 	case <-ctx.Done():
 		if h.settings.timeout > 0 && !timer.Stop() {
 			<-timer.C
@@ -85,3 +129,30 @@ func (h *handler) Shutdown(ctx context.Context) (err error) {
 		return fmt.Errorf("%w: after %s", ErrTimeout, h.settings.timeout)
 	}
 }
+
+// NotifyEarlyExit registers the channel given to receive an
+// *EarlyExitError if the goroutine terminates before
+// Shutdown is called. Sending on the channel is abandoned if
+// Shutdown is called in the meantime.
+func (h *handler) NotifyEarlyExit(ch chan<- error) {
+	go func() {
+		select {
+		case <-h.ctx.Done():
+			return
+		case <-h.done:
+		}
+
+		if h.ctx.Err() != nil {
+			return // goroutine exited because of the shutdown
+		}
+
+		err := &EarlyExitError{
+			Name: h.name,
+			Err:  h.err,
+		}
+		select {
+		case ch <- err:
+		case <-h.ctx.Done():
+		}
+	}()
+}
@@ -24,6 +24,103 @@ func Test_Handler(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func Test_HandlerWithError(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil error", func(t *testing.T) {
+		t.Parallel()
+
+		h, ctx, done := NewWithError("name", OptionTimeout(time.Hour))
+
+		go func(ctx context.Context) {
+			<-ctx.Done()
+			done <- nil
+		}(ctx)
+
+		err := h.Shutdown(context.Background())
+		require.NoError(t, err)
+	})
+
+	t.Run("done closed", func(t *testing.T) {
+		t.Parallel()
+
+		h, ctx, done := NewWithError("name", OptionTimeout(time.Hour))
+
+		go func(ctx context.Context) {
+			defer close(done)
+			<-ctx.Done()
+		}(ctx)
+
+		err := h.Shutdown(context.Background())
+		require.NoError(t, err)
+	})
+
+	t.Run("exit error", func(t *testing.T) {
+		t.Parallel()
+
+		errTest := errors.New("failed flushing buffer")
+		h, ctx, done := NewWithError("name", OptionTimeout(time.Hour))
+
+		go func(ctx context.Context) {
+			<-ctx.Done()
+			done <- errTest
+		}(ctx)
+
+		err := h.Shutdown(context.Background())
+		require.Error(t, err)
+		assert.ErrorIs(t, err, errTest)
+		assert.Equal(t, "goroutine exited with error: failed flushing buffer", err.Error())
+	})
+}
+
+func Test_handler_NotifyEarlyExit(t *testing.T) {
+	t.Parallel()
+
+	t.Run("early exit", func(t *testing.T) {
+		t.Parallel()
+
+		errTest := errors.New("upstream closed")
+		h := Go("name", func(ctx context.Context) error {
+			return errTest
+		}, OptionTimeout(time.Hour))
+
+		earlyExits := make(chan error)
+		h.(*handler).NotifyEarlyExit(earlyExits)
+
+		err := <-earlyExits
+		var earlyExitErr *EarlyExitError
+		require.ErrorAs(t, err, &earlyExitErr)
+		assert.Equal(t, "name", earlyExitErr.Name)
+		assert.ErrorIs(t, err, errTest)
+		assert.Equal(t, "name terminated before shutdown: goroutine exited with error: upstream closed", err.Error())
+
+		err = h.Shutdown(context.Background())
+		assert.ErrorIs(t, err, errTest)
+	})
+
+	t.Run("exit from shutdown", func(t *testing.T) {
+		t.Parallel()
+
+		h, ctx, done := New("name", OptionTimeout(time.Hour))
+		go func() {
+			defer close(done)
+			<-ctx.Done()
+		}()
+
+		earlyExits := make(chan error, 1)
+		h.(*handler).NotifyEarlyExit(earlyExits)
+
+		err := h.Shutdown(context.Background())
+		require.NoError(t, err)
+
+		select {
+		case err := <-earlyExits:
+			t.Errorf("unexpected early exit error: %s", err)
+		case <-time.After(10 * time.Millisecond):
+		}
+	})
+}
+
 func Test_New(t *testing.T) {
 	t.Parallel()
 
@@ -40,8 +137,9 @@ func Test_New(t *testing.T) {
 	assert.Equal(t, name, impl.name)
 
 	expectedSettings := settings{
-		timeout:  time.Second,
-		critical: true,
+		timeout:     time.Second,
+		critical:    true,
+		gracePeriod: time.Second,
 	}
 	assert.Equal(t, expectedSettings, impl.settings)
 	// cannot assert cancel and done as they are hidden away.
@@ -70,6 +168,18 @@ func Test_handler_IsCritical(t *testing.T) {
 	assert.Equal(t, critical, c)
 }
 
+func Test_handler_Timeout(t *testing.T) {
+	t.Parallel()
+	const timeout = time.Second
+
+	h := &handler{
+		settings: settings{timeout: timeout},
+	}
+	d := h.Timeout()
+
+	assert.Equal(t, timeout, d)
+}
+
 func Test_handler_Shutdown(t *testing.T) {
 	t.Parallel()
 
@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/qdm12/goshutdown/goroutine (interfaces: Handler)
+// Source: github.com/qdm12/goshutdown/goroutine (interfaces: Handler,TwoPhaseHandler,ReadyHandler)
 
 // Package mock_goroutine is a generated GoMock package.
 package mock_goroutine
@@ -9,6 +9,7 @@ import (
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
+	goroutine "github.com/qdm12/goshutdown/goroutine"
 )
 
 // MockHandler is a mock of Handler interface.
@@ -75,3 +76,161 @@ func (mr *MockHandlerMockRecorder) Shutdown(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Shutdown", reflect.TypeOf((*MockHandler)(nil).Shutdown), arg0)
 }
+
+// MockTwoPhaseHandler is a mock of TwoPhaseHandler interface.
+type MockTwoPhaseHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockTwoPhaseHandlerMockRecorder
+}
+
+// MockTwoPhaseHandlerMockRecorder is the mock recorder for MockTwoPhaseHandler.
+type MockTwoPhaseHandlerMockRecorder struct {
+	mock *MockTwoPhaseHandler
+}
+
+// NewMockTwoPhaseHandler creates a new mock instance.
+func NewMockTwoPhaseHandler(ctrl *gomock.Controller) *MockTwoPhaseHandler {
+	mock := &MockTwoPhaseHandler{ctrl: ctrl}
+	mock.recorder = &MockTwoPhaseHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTwoPhaseHandler) EXPECT() *MockTwoPhaseHandlerMockRecorder {
+	return m.recorder
+}
+
+// ExitPhase mocks base method.
+func (m *MockTwoPhaseHandler) ExitPhase() goroutine.Phase {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExitPhase")
+	ret0, _ := ret[0].(goroutine.Phase)
+	return ret0
+}
+
+// ExitPhase indicates an expected call of ExitPhase.
+func (mr *MockTwoPhaseHandlerMockRecorder) ExitPhase() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExitPhase", reflect.TypeOf((*MockTwoPhaseHandler)(nil).ExitPhase))
+}
+
+// IsCritical mocks base method.
+func (m *MockTwoPhaseHandler) IsCritical() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsCritical")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsCritical indicates an expected call of IsCritical.
+func (mr *MockTwoPhaseHandlerMockRecorder) IsCritical() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsCritical", reflect.TypeOf((*MockTwoPhaseHandler)(nil).IsCritical))
+}
+
+// Name mocks base method.
+func (m *MockTwoPhaseHandler) Name() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Name")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Name indicates an expected call of Name.
+func (mr *MockTwoPhaseHandlerMockRecorder) Name() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Name", reflect.TypeOf((*MockTwoPhaseHandler)(nil).Name))
+}
+
+// Shutdown mocks base method.
+func (m *MockTwoPhaseHandler) Shutdown(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Shutdown", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Shutdown indicates an expected call of Shutdown.
+func (mr *MockTwoPhaseHandlerMockRecorder) Shutdown(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Shutdown", reflect.TypeOf((*MockTwoPhaseHandler)(nil).Shutdown), arg0)
+}
+
+// MockReadyHandler is a mock of ReadyHandler interface.
+type MockReadyHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockReadyHandlerMockRecorder
+}
+
+// MockReadyHandlerMockRecorder is the mock recorder for MockReadyHandler.
+type MockReadyHandlerMockRecorder struct {
+	mock *MockReadyHandler
+}
+
+// NewMockReadyHandler creates a new mock instance.
+func NewMockReadyHandler(ctrl *gomock.Controller) *MockReadyHandler {
+	mock := &MockReadyHandler{ctrl: ctrl}
+	mock.recorder = &MockReadyHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReadyHandler) EXPECT() *MockReadyHandlerMockRecorder {
+	return m.recorder
+}
+
+// IsCritical mocks base method.
+func (m *MockReadyHandler) IsCritical() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsCritical")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsCritical indicates an expected call of IsCritical.
+func (mr *MockReadyHandlerMockRecorder) IsCritical() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsCritical", reflect.TypeOf((*MockReadyHandler)(nil).IsCritical))
+}
+
+// Name mocks base method.
+func (m *MockReadyHandler) Name() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Name")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Name indicates an expected call of Name.
+func (mr *MockReadyHandlerMockRecorder) Name() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Name", reflect.TypeOf((*MockReadyHandler)(nil).Name))
+}
+
+// Shutdown mocks base method.
+func (m *MockReadyHandler) Shutdown(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Shutdown", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Shutdown indicates an expected call of Shutdown.
+func (mr *MockReadyHandlerMockRecorder) Shutdown(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Shutdown", reflect.TypeOf((*MockReadyHandler)(nil).Shutdown), arg0)
+}
+
+// StartAndWaitReady mocks base method.
+func (m *MockReadyHandler) StartAndWaitReady(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartAndWaitReady", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StartAndWaitReady indicates an expected call of StartAndWaitReady.
+func (mr *MockReadyHandlerMockRecorder) StartAndWaitReady(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartAndWaitReady", reflect.TypeOf((*MockReadyHandler)(nil).StartAndWaitReady), arg0)
+}
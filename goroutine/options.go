@@ -18,3 +18,24 @@ func OptionCritical() Option {
 		s.critical = true
 	}
 }
+
+// IsCritical returns true if the options given mark the goroutine
+// as critical, for example to know the criticality of a goroutine
+// handler before creating it.
+func IsCritical(options ...Option) bool {
+	settings := newSettings()
+	for _, option := range options {
+		option(&settings)
+	}
+	return settings.critical
+}
+
+// OptionGracePeriod sets the grace period for a two phase goroutine,
+// which is the time to wait for the goroutine to exit after canceling
+// its graceful context, before canceling its forced context.
+// Note the grace period defaults to one second.
+func OptionGracePeriod(gracePeriod time.Duration) Option {
+	return func(s *settings) {
+		s.gracePeriod = gracePeriod
+	}
+}
@@ -0,0 +1,37 @@
+package goroutine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_IsCritical(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		options  []Option
+		critical bool
+	}{
+		"no option": {},
+		"not critical": {
+			options: []Option{OptionTimeout(time.Hour)},
+		},
+		"critical": {
+			options:  []Option{OptionTimeout(time.Hour), OptionCritical()},
+			critical: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			critical := IsCritical(testCase.options...)
+
+			assert.Equal(t, testCase.critical, critical)
+		})
+	}
+}
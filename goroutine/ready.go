@@ -0,0 +1,86 @@
+package goroutine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ReadyHandler handles a goroutine shutdown handler for a goroutine
+// launched by the handler itself, and signaling when it is ready.
+type ReadyHandler interface {
+	Handler
+	// StartAndWaitReady launches the goroutine if it is not launched
+	// already, and waits for it to signal it is ready. It returns an
+	// error wrapping ErrExitedBeforeReady if the goroutine exits before
+	// signaling it is ready, and the context error if the context is done.
+	StartAndWaitReady(ctx context.Context) (err error)
+}
+
+// NewReady creates a goroutine handler launching the function given in
+// a goroutine when StartAndWaitReady is first called, such that the
+// goroutine can be started by a parent handler. The function should close
+// the ready channel given once it is initialized, for example once its
+// listener is bound. Panics and errors are handled as they are with Go.
+// If Shutdown is called before the goroutine is launched, the goroutine
+// is never launched and Shutdown returns nil.
+func NewReady(name string, fn func(ctx context.Context, ready chan<- struct{}) error,
+	options ...Option) ReadyHandler {
+	h, _, done := newHandler(name, options...)
+	return &readyHandler{
+		handler:    h,
+		signalDone: done,
+		fn:         fn,
+		ready:      make(chan struct{}),
+	}
+}
+
+type readyHandler struct {
+	*handler
+	// signalDone is the sending side of the handler done channel,
+	// closed once the goroutine exits or if it is never launched.
+	signalDone chan<- struct{}
+	fn         func(ctx context.Context, ready chan<- struct{}) error
+	ready      chan struct{}
+	// startOnce is used to either launch the goroutine
+	// or mark it as never launched on shutdown.
+	startOnce sync.Once
+}
+
+// ErrExitedBeforeReady is the error when the goroutine exits
+// before signaling it is ready.
+var ErrExitedBeforeReady = errors.New("goroutine exited before being ready")
+
+func (h *readyHandler) StartAndWaitReady(ctx context.Context) (err error) {
+	h.startOnce.Do(func() {
+		go h.run(h.ctx, h.signalDone, func(ctx context.Context) error {
+			return h.fn(ctx, h.ready)
+		})
+	})
+
+	select {
+	case <-h.ready:
+		return nil
+	case <-h.done:
+		select {
+		case <-h.ready:
+			return nil // goroutine exited right after being ready
+		default:
+		}
+		if h.err == nil {
+			return ErrExitedBeforeReady
+		}
+		return fmt.Errorf("%w: %s", ErrExitedBeforeReady, h.err)
+	case <-ctx.Done():
+		return ctx.Err() //nolint:wrapcheck
+	}
+}
+
+func (h *readyHandler) Shutdown(ctx context.Context) (err error) {
+	h.cancel()
+	h.startOnce.Do(func() {
+		close(h.signalDone) // goroutine never launched
+	})
+	return h.wait(ctx)
+}
@@ -0,0 +1,94 @@
+package goroutine
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_NewReady(t *testing.T) {
+	t.Parallel()
+
+	errTest := errors.New("test error")
+
+	t.Run("ready", func(t *testing.T) {
+		t.Parallel()
+
+		launched := make(chan struct{})
+		h := NewReady("name", func(ctx context.Context, ready chan<- struct{}) error {
+			close(launched)
+			close(ready)
+			<-ctx.Done()
+			return nil
+		}, OptionTimeout(time.Hour))
+
+		select {
+		case <-launched:
+			t.Fatal("goroutine launched before StartAndWaitReady")
+		case <-time.After(time.Millisecond):
+		}
+
+		err := h.StartAndWaitReady(context.Background())
+		require.NoError(t, err)
+
+		// Calling it again does not launch the goroutine again
+		err = h.StartAndWaitReady(context.Background())
+		require.NoError(t, err)
+
+		err = h.Shutdown(context.Background())
+		assert.NoError(t, err)
+	})
+
+	t.Run("exited before ready", func(t *testing.T) {
+		t.Parallel()
+
+		h := NewReady("name", func(ctx context.Context, ready chan<- struct{}) error {
+			return errTest
+		}, OptionTimeout(time.Hour))
+
+		err := h.StartAndWaitReady(context.Background())
+		require.Error(t, err)
+		assert.ErrorIs(t, err, ErrExitedBeforeReady)
+		assert.EqualError(t, err, "goroutine exited before being ready: "+
+			"goroutine exited with error: test error")
+
+		err = h.Shutdown(context.Background())
+		assert.ErrorIs(t, err, errTest)
+	})
+
+	t.Run("context canceled", func(t *testing.T) {
+		t.Parallel()
+
+		h := NewReady("name", func(ctx context.Context, ready chan<- struct{}) error {
+			<-ctx.Done()
+			return nil
+		}, OptionTimeout(time.Hour))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := h.StartAndWaitReady(ctx)
+		assert.ErrorIs(t, err, context.Canceled)
+
+		err = h.Shutdown(context.Background())
+		assert.NoError(t, err)
+	})
+
+	t.Run("shutdown before start", func(t *testing.T) {
+		t.Parallel()
+
+		h := NewReady("name", func(ctx context.Context, ready chan<- struct{}) error {
+			panic("should not be launched")
+		}, OptionTimeout(time.Hour))
+
+		err := h.Shutdown(context.Background())
+		require.NoError(t, err)
+
+		err = h.StartAndWaitReady(context.Background())
+		assert.ErrorIs(t, err, ErrExitedBeforeReady)
+	})
+}
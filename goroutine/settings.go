@@ -10,10 +10,15 @@ type settings struct {
 	// critical can be set to true to indicate the shutdown process should exit if
 	// this goroutine cannot be terminated.
 	critical bool
+	// gracePeriod is the time to wait for a two phase goroutine to exit
+	// after canceling its graceful context, before canceling its forced
+	// context. It defaults to 1s if left unset.
+	gracePeriod time.Duration
 }
 
 func newSettings() settings {
 	return settings{
-		timeout: time.Second,
+		timeout:     time.Second,
+		gracePeriod: time.Second,
 	}
 }
@@ -13,7 +13,8 @@ func Test_newSettings(t *testing.T) {
 	s := newSettings()
 
 	expected := settings{
-		timeout: time.Second,
+		timeout:     time.Second,
+		gracePeriod: time.Second,
 	}
 
 	assert.Equal(t, expected, s)
@@ -0,0 +1,109 @@
+package goroutine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// TwoPhaseHandler handles a goroutine shutdown handler with
+// a graceful shutdown phase followed by a forced shutdown phase.
+type TwoPhaseHandler interface {
+	Handler
+	// ExitPhase returns the phase the goroutine exited in during
+	// the last Shutdown call, and PhaseNone if it did not exit.
+	// It should only be called once Shutdown has returned.
+	ExitPhase() Phase
+}
+
+// Phase is the shutdown phase of a two phase goroutine.
+type Phase uint8
+
+const (
+	// PhaseNone indicates the goroutine did not exit during the shutdown.
+	PhaseNone Phase = iota
+	// PhaseGraceful indicates the goroutine exited during the grace period,
+	// after its graceful context got canceled.
+	PhaseGraceful
+	// PhaseForced indicates the goroutine exited after its forced
+	// context got canceled.
+	PhaseForced
+)
+
+func (p Phase) String() string {
+	switch p {
+	case PhaseNone:
+		return "none"
+	case PhaseGraceful:
+		return "graceful"
+	case PhaseForced:
+		return "forced"
+	default:
+		return "unknown"
+	}
+}
+
+// NewTwoPhase creates a two phase goroutine handler. It returns the handler
+// as well as the graceful context, the forced context and the done signal
+// channel to use in the actual goroutine. On shutdown, the graceful context
+// is canceled first, so the goroutine can finish its in-flight work. If the
+// goroutine does not exit within the grace period, the forced context is
+// canceled so the goroutine aborts its work, and the goroutine is then given
+// the handler timeout to exit.
+func NewTwoPhase(name string, options ...Option) (h TwoPhaseHandler,
+	gracefulCtx, forcedCtx context.Context, done chan<- struct{}) {
+	graceful, gracefulCtx, signalDone := newHandler(name, options...)
+	forcedCtx, cancelForced := context.WithCancel(context.Background())
+
+	h = &twoPhaseHandler{
+		handler:      graceful,
+		cancelForced: cancelForced,
+	}
+
+	return h, gracefulCtx, forcedCtx, signalDone
+}
+
+type twoPhaseHandler struct {
+	*handler
+	cancelForced context.CancelFunc
+	phase        Phase
+}
+
+func (h *twoPhaseHandler) ExitPhase() Phase {
+	return h.phase
+}
+
+func (h *twoPhaseHandler) Shutdown(ctx context.Context) (err error) {
+	h.phase = PhaseNone
+	defer h.cancelForced()
+
+	h.cancel()
+
+	timer := time.NewTimer(h.settings.gracePeriod)
+	select {
+	case <-h.done:
+		timer.Stop()
+		h.phase = PhaseGraceful
+		return h.err
+	case <-ctx.Done():
+		timer.Stop()
+		return ctx.Err() //nolint:wrapcheck
+	case <-timer.C:
+	}
+
+	h.cancelForced()
+	err = h.wait(ctx)
+
+	select {
+	case <-h.done:
+		h.phase = PhaseForced
+	default:
+	}
+
+	if errors.Is(err, ErrTimeout) {
+		return fmt.Errorf("%w: after grace period of %s and forced timeout of %s",
+			ErrTimeout, h.settings.gracePeriod, h.settings.timeout)
+	}
+	return err
+}
@@ -0,0 +1,84 @@
+package goroutine
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Phase_String(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "none", PhaseNone.String())
+	assert.Equal(t, "graceful", PhaseGraceful.String())
+	assert.Equal(t, "forced", PhaseForced.String())
+	assert.Equal(t, "unknown", Phase(99).String())
+}
+
+func Test_TwoPhaseHandler(t *testing.T) {
+	t.Parallel()
+
+	t.Run("graceful exit", func(t *testing.T) {
+		t.Parallel()
+
+		h, gracefulCtx, forcedCtx, done := NewTwoPhase("name",
+			OptionGracePeriod(time.Hour), OptionTimeout(time.Hour))
+
+		go func() {
+			defer close(done)
+			<-gracefulCtx.Done()
+		}()
+
+		err := h.Shutdown(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, PhaseGraceful, h.ExitPhase())
+		assert.Error(t, forcedCtx.Err())
+	})
+
+	t.Run("forced exit", func(t *testing.T) {
+		t.Parallel()
+
+		h, _, forcedCtx, done := NewTwoPhase("name",
+			OptionGracePeriod(time.Millisecond), OptionTimeout(time.Hour))
+
+		go func() {
+			defer close(done)
+			<-forcedCtx.Done()
+		}()
+
+		err := h.Shutdown(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, PhaseForced, h.ExitPhase())
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		t.Parallel()
+
+		h, _, _, _ := NewTwoPhase("name",
+			OptionGracePeriod(time.Millisecond), OptionTimeout(time.Millisecond))
+
+		err := h.Shutdown(context.Background())
+		require.Error(t, err)
+		assert.ErrorIs(t, err, ErrTimeout)
+		assert.Equal(t, "goroutine shutdown timed out: after grace period of 1ms and forced timeout of 1ms", err.Error())
+		assert.Equal(t, PhaseNone, h.ExitPhase())
+	})
+
+	t.Run("shutdown context canceled", func(t *testing.T) {
+		t.Parallel()
+
+		h, _, forcedCtx, _ := NewTwoPhase("name",
+			OptionGracePeriod(time.Hour), OptionTimeout(time.Hour))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := h.Shutdown(ctx)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, PhaseNone, h.ExitPhase())
+		assert.Error(t, forcedCtx.Err())
+	})
+}
@@ -0,0 +1,403 @@
+// Package graph defines a shutdown handler for a dependency graph
+// of shutdown handlers.
+package graph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/qdm12/goshutdown/event"
+	"github.com/qdm12/goshutdown/handler"
+	"github.com/qdm12/goshutdown/internal/ready"
+	"github.com/qdm12/goshutdown/report"
+)
+
+//go:generate mockgen -destination=mock_$GOPACKAGE/$GOFILE . Handler
+
+// Handler handles a dependency graph of shutdown handlers.
+// The handler created by New also implements handler.ReadyStarter,
+// handler.Parent and handler.Timeouter.
+type Handler interface {
+	// Name returns the name set for this graph handler.
+	Name() string
+	// IsCritical returns true if the graph handler is critical and must be terminated
+	// before continuing other external shutdown procedures.
+	IsCritical() bool
+	// Shutdown initiates the shutdown process, shutting down each handler only
+	// once all its dependents are shut down, and with as many handlers shut down
+	// in parallel as possible. If a critical handler fails to shutdown, the handlers
+	// waiting for it are not shut down, but the other handlers are still shut down.
+	// It returns a *ShutdownError if one or more handlers did not complete on time,
+	// and nil otherwise.
+	Shutdown(ctx context.Context) (err error)
+	// ShutdownReport shuts down the graph as Shutdown does, and returns
+	// the shutdown report of the graph and of its nested handlers.
+	ShutdownReport(ctx context.Context) (report *report.Report, err error)
+	// Add adds an handler to the graph, with the handlers depending on it
+	// which must be shut down before it. Handlers not yet in the graph
+	// are added to it. It returns an error wrapping ErrCycle if one of the
+	// dependents given is already shut down after the handler given,
+	// and nothing is changed in the graph in this case.
+	Add(h handler.Handler, dependents ...handler.Handler) (err error)
+	// NotifyEarlyExit registers the channel given to receive a
+	// *goroutine.EarlyExitError for each goroutine of the handlers
+	// terminating before its shutdown is initiated. This applies
+	// recursively to all the handlers implementing the
+	// handler.EarlyExitNotifier interface, including the ones added
+	// after this call.
+	NotifyEarlyExit(ch chan<- error)
+}
+
+type node struct {
+	handler handler.Handler
+	// dependents are the nodes to shutdown before this node.
+	dependents []*node
+	// dependencies are the nodes to shutdown after this node.
+	dependencies []*node
+}
+
+type graphHandler struct {
+	name     string
+	settings settings
+	// nodes are the nodes of the graph, in the order they were added.
+	nodes []*node
+	// order are the nodes of the graph in a topological shutdown order,
+	// where each node comes after all its dependents.
+	order       []*node
+	handlerNode map[handler.Handler]*node
+	// earlyExitChannels are the channels registered with NotifyEarlyExit.
+	earlyExitChannels []chan<- error
+}
+
+// New creates a new graph Handler with the given name and options.
+func New(name string, options ...Option) Handler {
+	settings := newSettings()
+	for _, option := range options {
+		option(&settings)
+	}
+
+	return &graphHandler{
+		name:        name,
+		settings:    settings,
+		handlerNode: make(map[handler.Handler]*node),
+	}
+}
+
+func (h *graphHandler) Name() string {
+	return h.name
+}
+
+func (h *graphHandler) IsCritical() bool {
+	return h.settings.critical
+}
+
+// Children returns a copy of the handlers of the graph, in a topological
+// shutdown order where each handler comes after all its dependents.
+func (h *graphHandler) Children() []handler.Handler {
+	children := make([]handler.Handler, len(h.order))
+	for i, n := range h.order {
+		children[i] = n.handler
+	}
+	return children
+}
+
+// Timeout returns the timeout of the graph, where 0 indicates no timeout.
+func (h *graphHandler) Timeout() time.Duration {
+	return h.settings.timeout
+}
+
+// ErrCycle is the error when adding an handler would create a dependency cycle.
+var ErrCycle = errors.New("dependency cycle")
+
+func (h *graphHandler) Add(child handler.Handler, dependents ...handler.Handler) (err error) {
+	for _, dependent := range dependents {
+		if dependent == child {
+			return fmt.Errorf("%w: %s depends on itself", ErrCycle, child.Name())
+		}
+	}
+
+	nodesCount, previousOrder := len(h.nodes), h.order
+	childNode := h.getOrAddNode(child)
+	for i, dependent := range dependents {
+		dependentNode := h.getOrAddNode(dependent)
+		childNode.dependents = append(childNode.dependents, dependentNode)
+		dependentNode.dependencies = append(dependentNode.dependencies, childNode)
+
+		order, ok := topologicalOrder(h.nodes)
+		if !ok {
+			h.rollback(nodesCount, childNode, dependents[:i+1])
+			h.order = previousOrder
+			return fmt.Errorf("%w: %s is already shut down before %s",
+				ErrCycle, child.Name(), dependent.Name())
+		}
+		h.order = order
+	}
+
+	if len(dependents) == 0 && len(h.nodes) > nodesCount {
+		h.order = append(h.order, childNode)
+	}
+
+	for _, n := range h.nodes[nodesCount:] {
+		for _, ch := range h.earlyExitChannels {
+			notifyEarlyExit(n.handler, ch)
+		}
+	}
+
+	return nil
+}
+
+func (h *graphHandler) getOrAddNode(child handler.Handler) *node {
+	n, ok := h.handlerNode[child]
+	if ok {
+		return n
+	}
+
+	n = &node{handler: child}
+	h.handlerNode[child] = n
+	h.nodes = append(h.nodes, n)
+	return n
+}
+
+// rollback removes the edges from the child node to the dependents
+// given, which were the last edges added, as well as the nodes added
+// after the graph had nodesCount nodes.
+func (h *graphHandler) rollback(nodesCount int, childNode *node,
+	dependents []handler.Handler) {
+	for i := len(dependents) - 1; i >= 0; i-- {
+		dependentNode := h.handlerNode[dependents[i]]
+		childNode.dependents = childNode.dependents[:len(childNode.dependents)-1]
+		dependentNode.dependencies = dependentNode.dependencies[:len(dependentNode.dependencies)-1]
+	}
+
+	for _, n := range h.nodes[nodesCount:] {
+		delete(h.handlerNode, n.handler)
+	}
+	h.nodes = h.nodes[:nodesCount]
+}
+
+// topologicalOrder returns the nodes given in a shutdown order, where each
+// node comes after all its dependents, using Kahn's algorithm. It returns
+// false if the nodes contain a dependency cycle.
+func topologicalOrder(nodes []*node) (order []*node, ok bool) {
+	order = make([]*node, 0, len(nodes))
+	pendingDependents := make(map[*node]int, len(nodes))
+	for _, n := range nodes {
+		pendingDependents[n] = len(n.dependents)
+		if len(n.dependents) == 0 {
+			order = append(order, n)
+		}
+	}
+
+	for i := 0; i < len(order); i++ {
+		for _, dependency := range order[i].dependencies {
+			pendingDependents[dependency]--
+			if pendingDependents[dependency] == 0 {
+				order = append(order, dependency)
+			}
+		}
+	}
+
+	return order, len(order) == len(nodes)
+}
+
+var (
+	// ErrCriticalTimeout is the error when a critical shutdown timed out in the graph.
+	ErrCriticalTimeout = errors.New("critical graph handler timed out")
+	// ErrTimeout is the error when one or more shutdown timed out in the graph.
+	ErrTimeout = errors.New("graph shutdown timed out")
+	// ErrReadyTimeout is the error when a handler of the graph is not ready
+	// within the ready timeout of the graph.
+	ErrReadyTimeout = errors.New("readiness timed out")
+)
+
+// StartAndWaitReady starts the handlers of the graph implementing the
+// handler.ReadyStarter interface, such as goroutine.ReadyHandler, in the
+// reverse order of their shutdown: each handler is started once all the
+// handlers shut down after it are ready, with as many handlers started in
+// parallel as possible. Each handler is given the ready timeout of the graph
+// to be ready. It returns an error for the first handler failing to be ready,
+// in which case the handlers not started yet are not started.
+func (h *graphHandler) StartAndWaitReady(ctx context.Context) (err error) {
+	type readyStatus struct {
+		node *node
+		err  error
+	}
+	readied := make(chan readyStatus, len(h.order))
+	running := 0
+	launch := func(n *node) {
+		running++
+		go func() {
+			var err error
+			starter, ok := n.handler.(handler.ReadyStarter)
+			if ok {
+				err = ready.StartAndWait(ctx, starter, h.settings.readyTimeout, ErrReadyTimeout)
+			}
+			readied <- readyStatus{node: n, err: err}
+		}()
+	}
+
+	// pendingDependencies is the number of dependencies not ready yet for each node.
+	pendingDependencies := make(map[*node]int, len(h.order))
+	for _, n := range h.order {
+		pendingDependencies[n] = len(n.dependencies)
+		if len(n.dependencies) == 0 {
+			launch(n)
+		}
+	}
+
+	for running > 0 {
+		status := <-readied
+		running--
+		if status.err != nil && err == nil {
+			err = fmt.Errorf("%s: %w", status.node.handler.Name(), status.err)
+		}
+		if err != nil {
+			continue // do not start more handlers
+		}
+
+		for _, dependent := range status.node.dependents {
+			pendingDependencies[dependent]--
+			if pendingDependencies[dependent] == 0 {
+				launch(dependent)
+			}
+		}
+	}
+
+	return err
+}
+
+func (h *graphHandler) Shutdown(ctx context.Context) (err error) {
+	_, err = h.ShutdownReport(ctx)
+	return err
+}
+
+func (h *graphHandler) ShutdownReport(ctx context.Context) (
+	rep *report.Report, err error) {
+	start := time.Now()
+	rep = &report.Report{
+		Name:     h.name,
+		Kind:     report.KindGraph,
+		Critical: h.settings.critical,
+		Children: make([]*report.Report, 0, len(h.order)),
+	}
+	ctx, events := event.NewScope(ctx, h.name, h.settings.critical, h.settings.observers...)
+	events.ShutdownStarted()
+	defer func() {
+		rep.Duration = time.Since(start)
+		rep.SetError(err)
+		events.ShutdownFinished(rep, err)
+	}()
+
+	ctx, cancel := context.WithTimeout(ctx, h.settings.timeout)
+	defer cancel()
+
+	type completionStatus struct {
+		node   *node
+		id     uint64
+		report *report.Report
+		err    error
+	}
+	completed := make(chan completionStatus, len(h.order))
+	running := 0
+	launch := func(n *node) {
+		running++
+		name, critical := n.handler.Name(), n.handler.IsCritical()
+		childCtx, id := events.HandlerStarted(ctx, name, critical)
+		go func() {
+			childReport, err := report.Shutdown(childCtx, n.handler, name, critical)
+			completed <- completionStatus{
+				node:   n,
+				id:     id,
+				report: childReport,
+				err:    err,
+			}
+		}()
+	}
+
+	// pendingDependents is the number of dependents not shut down yet for each node.
+	pendingDependents := make(map[*node]int, len(h.order))
+	for _, n := range h.order {
+		pendingDependents[n] = len(n.dependents)
+		if len(n.dependents) == 0 {
+			launch(n)
+		}
+	}
+
+	results := make([]handler.Result, 0, len(h.order))
+	var criticalFailure *handler.Result
+	failed := false
+	skipped := make(map[*node]struct{})
+	var skip func(n *node)
+	skip = func(n *node) {
+		for _, dependency := range n.dependencies {
+			if _, ok := skipped[dependency]; ok {
+				continue
+			}
+			skipped[dependency] = struct{}{}
+			name, critical := dependency.handler.Name(), dependency.handler.IsCritical()
+			events.HandlerSkipped(name, critical)
+			rep.Children = append(rep.Children, report.Skipped(name, critical))
+			skip(dependency)
+		}
+	}
+
+	for running > 0 {
+		status := <-completed
+		running--
+		events.HandlerFinished(status.id, status.report, status.err)
+		rep.Children = append(rep.Children, status.report)
+		result := status.report.Result(status.err)
+		results = append(results, result)
+
+		if status.err == nil {
+			h.settings.onSuccess(result.Name)
+		} else {
+			failed = true
+			h.settings.onFailure(result.Name, result.Err)
+			if result.Critical {
+				if criticalFailure == nil {
+					criticalFailure = &result
+				}
+				events.CriticalAbort(status.id, result.Name, result.Err)
+				skip(status.node)
+				continue
+			}
+		}
+
+		for _, dependency := range status.node.dependencies {
+			if _, ok := skipped[dependency]; ok {
+				continue
+			}
+			pendingDependents[dependency]--
+			if pendingDependents[dependency] == 0 {
+				launch(dependency)
+			}
+		}
+	}
+
+	if !failed {
+		return rep, nil
+	}
+
+	return rep, &ShutdownError{
+		Name:            h.name,
+		Results:         results,
+		CriticalFailure: criticalFailure,
+	}
+}
+
+func (h *graphHandler) NotifyEarlyExit(ch chan<- error) {
+	h.earlyExitChannels = append(h.earlyExitChannels, ch)
+	for _, n := range h.nodes {
+		notifyEarlyExit(n.handler, ch)
+	}
+}
+
+func notifyEarlyExit(h handler.Handler, ch chan<- error) {
+	notifier, ok := h.(handler.EarlyExitNotifier)
+	if ok {
+		notifier.NotifyEarlyExit(ch)
+	}
+}
@@ -0,0 +1,364 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/qdm12/goshutdown/event"
+	"github.com/qdm12/goshutdown/goroutine"
+	"github.com/qdm12/goshutdown/handler"
+	"github.com/qdm12/goshutdown/handler/mock_handler"
+	"github.com/qdm12/goshutdown/report"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_New(t *testing.T) {
+	t.Parallel()
+
+	const name = "name"
+
+	expected := &graphHandler{
+		name: name,
+		settings: settings{
+			timeout:      time.Hour,
+			onSuccess:    defaultOnSuccess,
+			onFailure:    defaultOnFailure,
+			readyTimeout: 10 * time.Second,
+		},
+		handlerNode: map[handler.Handler]*node{},
+	}
+
+	intf := New(name, OptionTimeout(time.Hour))
+
+	impl, ok := intf.(*graphHandler)
+	require.True(t, ok)
+
+	assertSettingsEqual(t, &expected.settings, &impl.settings)
+
+	assert.Equal(t, expected, impl)
+}
+
+func Test_graphHandler_Name(t *testing.T) {
+	t.Parallel()
+	const name = "name"
+
+	h := &graphHandler{
+		name: name,
+	}
+	n := h.Name()
+
+	assert.Equal(t, name, n)
+}
+
+func Test_graphHandler_IsCritical(t *testing.T) {
+	t.Parallel()
+	const critical = true
+
+	h := &graphHandler{
+		settings: settings{critical: critical},
+	}
+	c := h.IsCritical()
+
+	assert.Equal(t, critical, c)
+}
+
+func Test_graphHandler_Add(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	newHandler := func(name string) *mock_handler.MockHandler {
+		h := mock_handler.NewMockHandler(ctrl)
+		h.EXPECT().Name().Return(name).AnyTimes()
+		return h
+	}
+	a, b, c, d := newHandler("A"), newHandler("B"), newHandler("C"), newHandler("D")
+
+	g := New("graph")
+
+	err := g.Add(a, a)
+	assert.ErrorIs(t, err, ErrCycle)
+	assert.EqualError(t, err, "dependency cycle: A depends on itself")
+
+	// a is shut down after b, which is shut down after c
+	err = g.Add(a, b)
+	require.NoError(t, err)
+	err = g.Add(b, c)
+	require.NoError(t, err)
+
+	err = g.Add(c, a)
+	assert.ErrorIs(t, err, ErrCycle)
+	assert.EqualError(t, err, "dependency cycle: C is already shut down before A")
+
+	// the new node d and the edge from c to d are rolled back
+	err = g.Add(c, d, a)
+	assert.EqualError(t, err, "dependency cycle: C is already shut down before A")
+
+	impl, ok := g.(*graphHandler)
+	require.True(t, ok)
+	require.Len(t, impl.nodes, 3)
+	nodeA, nodeB, nodeC := impl.nodes[0], impl.nodes[1], impl.nodes[2]
+	assert.Equal(t, []*node{nodeB}, nodeA.dependents)
+	assert.Empty(t, nodeA.dependencies)
+	assert.Equal(t, []*node{nodeC}, nodeB.dependents)
+	assert.Equal(t, []*node{nodeA}, nodeB.dependencies)
+	assert.Empty(t, nodeC.dependents)
+	assert.Equal(t, []*node{nodeB}, nodeC.dependencies)
+	assert.Equal(t, []*node{nodeC, nodeB, nodeA}, impl.order)
+	assert.Len(t, impl.handlerNode, 3)
+}
+
+func Test_graphHandler_Add_diamonds(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	// a chain of diamonds, where each top node is shut down after two
+	// middle nodes, both shut down after the bottom node which is the
+	// top node of the next diamond.
+	const diamonds = 64
+	g := New("graph")
+	first := mock_handler.NewMockHandler(ctrl)
+	first.EXPECT().Name().Return("first")
+	top := first
+	for i := 0; i < diamonds; i++ {
+		left := mock_handler.NewMockHandler(ctrl)
+		right := mock_handler.NewMockHandler(ctrl)
+		bottom := mock_handler.NewMockHandler(ctrl)
+		require.NoError(t, g.Add(top, left, right))
+		require.NoError(t, g.Add(left, bottom))
+		require.NoError(t, g.Add(right, bottom))
+		top = bottom
+	}
+	top.EXPECT().Name().Return("last")
+
+	err := g.Add(top, first)
+	assert.EqualError(t, err, "dependency cycle: last is already shut down before first")
+
+	impl, ok := g.(*graphHandler)
+	require.True(t, ok)
+	assert.Len(t, impl.order, 3*diamonds+1)
+}
+
+// shutdownRecorder records the order in which handlers are shut down.
+type shutdownRecorder struct {
+	mutex sync.Mutex
+	names []string
+}
+
+func (r *shutdownRecorder) newHandler(ctrl *gomock.Controller, name string,
+	critical bool, err error) *mock_handler.MockHandler {
+	h := mock_handler.NewMockHandler(ctrl)
+	h.EXPECT().Name().Return(name).AnyTimes()
+	h.EXPECT().IsCritical().Return(critical).AnyTimes()
+	h.EXPECT().Shutdown(gomock.Any()).DoAndReturn(func(ctx context.Context) error {
+		r.mutex.Lock()
+		defer r.mutex.Unlock()
+		r.names = append(r.names, name)
+		return err
+	}).MaxTimes(1)
+	return h
+}
+
+func (r *shutdownRecorder) indexOf(name string) int {
+	for i, recorded := range r.names {
+		if recorded == name {
+			return i
+		}
+	}
+	return -1
+}
+
+func Test_graphHandler_Shutdown(t *testing.T) {
+	t.Parallel()
+
+	t.Run("dependency order", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+
+		recorder := new(shutdownRecorder)
+		api := recorder.newHandler(ctrl, "api", false, nil)
+		worker := recorder.newHandler(ctrl, "worker", false, nil)
+		queue := recorder.newHandler(ctrl, "queue", false, nil)
+		database := recorder.newHandler(ctrl, "database", false, nil)
+		cache := recorder.newHandler(ctrl, "cache", false, nil)
+
+		g := New("graph")
+		require.NoError(t, g.Add(queue, api, worker))
+		require.NoError(t, g.Add(database, queue))
+		require.NoError(t, g.Add(cache, api))
+
+		rep, err := g.ShutdownReport(context.Background())
+		require.NoError(t, err)
+
+		require.Len(t, recorder.names, 5)
+		assert.Less(t, recorder.indexOf("api"), recorder.indexOf("queue"))
+		assert.Less(t, recorder.indexOf("worker"), recorder.indexOf("queue"))
+		assert.Less(t, recorder.indexOf("queue"), recorder.indexOf("database"))
+		assert.Less(t, recorder.indexOf("api"), recorder.indexOf("cache"))
+
+		assert.Equal(t, report.KindGraph, rep.Kind)
+		assert.Equal(t, report.StatusSuccess, rep.Status)
+		assert.Len(t, rep.Children, 5)
+	})
+
+	t.Run("critical failure skips dependencies only", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+
+		recorder := new(shutdownRecorder)
+		api := recorder.newHandler(ctrl, "api", false, nil)
+		worker := recorder.newHandler(ctrl, "worker", true, goroutine.ErrTimeout)
+		queue := recorder.newHandler(ctrl, "queue", false, nil)
+		database := recorder.newHandler(ctrl, "database", false, nil)
+		cache := recorder.newHandler(ctrl, "cache", false, nil)
+
+		g := New("graph")
+		require.NoError(t, g.Add(queue, api, worker))
+		require.NoError(t, g.Add(database, queue))
+		require.NoError(t, g.Add(cache, api))
+
+		rep, err := g.ShutdownReport(context.Background())
+		require.Error(t, err)
+		assert.ErrorIs(t, err, ErrCriticalTimeout)
+		assert.EqualError(t, err, "critical graph handler timed out: worker: goroutine shutdown timed out")
+
+		assert.ElementsMatch(t, []string{"api", "worker", "cache"}, recorder.names)
+
+		var skipped []string
+		for _, child := range rep.Children {
+			if child.Status == report.StatusSkipped {
+				skipped = append(skipped, child.Name)
+			}
+		}
+		assert.Equal(t, []string{"queue", "database"}, skipped)
+	})
+
+	t.Run("observer", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+
+		recorder := new(shutdownRecorder)
+		api := recorder.newHandler(ctrl, "api", true, goroutine.ErrTimeout)
+		database := recorder.newHandler(ctrl, "database", false, nil)
+
+		var events []string
+		g := New("graph", OptionObserver(event.ObserverFunc(func(e event.Event) {
+			events = append(events, string(e.Type)+" "+e.Path)
+		})))
+		require.NoError(t, g.Add(database, api))
+
+		err := g.Shutdown(context.Background())
+		require.Error(t, err)
+
+		expected := []string{
+			"shutdown_started graph",
+			"handler_started graph/api",
+			"handler_failed graph/api",
+			"critical_abort graph/api",
+			"handler_skipped graph/database",
+			"shutdown_finished graph",
+		}
+		assert.Equal(t, expected, events)
+	})
+
+	t.Run("non critical failure", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+
+		errTest := errors.New("test error")
+		recorder := new(shutdownRecorder)
+		api := recorder.newHandler(ctrl, "api", false, errTest)
+		database := recorder.newHandler(ctrl, "database", false, nil)
+
+		var failures []string
+		g := New("graph", OptionOnFailure(func(name string, err error) {
+			failures = append(failures, name)
+		}))
+		require.NoError(t, g.Add(database, api))
+
+		err := g.Shutdown(context.Background())
+		require.Error(t, err)
+		assert.ErrorIs(t, err, ErrTimeout)
+		assert.ErrorIs(t, err, errTest)
+		assert.EqualError(t, err, "graph shutdown timed out: api: test error")
+		assert.Equal(t, []string{"api", "database"}, recorder.names)
+		assert.Equal(t, []string{"api"}, failures)
+	})
+}
+
+func Test_graphHandler_StartAndWaitReady(t *testing.T) {
+	t.Parallel()
+
+	newReady := func(name string, started chan<- string,
+		readyErr error) goroutine.ReadyHandler {
+		return goroutine.NewReady(name, func(ctx context.Context, ready chan<- struct{}) error {
+			started <- name
+			if readyErr != nil {
+				return readyErr
+			}
+			close(ready)
+			<-ctx.Done()
+			return nil
+		})
+	}
+
+	t.Run("ready in dependency order", func(t *testing.T) {
+		t.Parallel()
+
+		started := make(chan string, 3)
+		api := newReady("api", started, nil)
+		queue := newReady("queue", started, nil)
+		database := newReady("database", started, nil)
+
+		g := New("graph")
+		require.NoError(t, g.Add(database, queue))
+		require.NoError(t, g.Add(queue, api))
+
+		err := g.(handler.ReadyStarter).StartAndWaitReady(context.Background())
+		require.NoError(t, err)
+		close(started)
+
+		var names []string
+		for name := range started {
+			names = append(names, name)
+		}
+		assert.Equal(t, []string{"database", "queue", "api"}, names)
+
+		err = g.Shutdown(context.Background())
+		require.NoError(t, err)
+	})
+
+	t.Run("not ready", func(t *testing.T) {
+		t.Parallel()
+
+		errTest := errors.New("test error")
+		started := make(chan string, 3)
+		api := newReady("api", started, nil)
+		queue := newReady("queue", started, errTest)
+		database := newReady("database", started, nil)
+
+		g := New("graph")
+		require.NoError(t, g.Add(database, queue))
+		require.NoError(t, g.Add(queue, api))
+
+		err := g.(handler.ReadyStarter).StartAndWaitReady(context.Background())
+		require.Error(t, err)
+		assert.ErrorIs(t, err, goroutine.ErrExitedBeforeReady)
+		assert.EqualError(t, err, "queue: goroutine exited before being ready: "+
+			"goroutine exited with error: test error")
+		close(started)
+
+		var names []string
+		for name := range started {
+			names = append(names, name)
+		}
+		assert.Equal(t, []string{"database", "queue"}, names)
+
+		err = g.Shutdown(context.Background())
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,125 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/qdm12/goshutdown/graph (interfaces: Handler)
+
+// Package mock_graph is a generated GoMock package.
+package mock_graph
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	handler "github.com/qdm12/goshutdown/handler"
+	report "github.com/qdm12/goshutdown/report"
+)
+
+// MockHandler is a mock of Handler interface.
+type MockHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockHandlerMockRecorder
+}
+
+// MockHandlerMockRecorder is the mock recorder for MockHandler.
+type MockHandlerMockRecorder struct {
+	mock *MockHandler
+}
+
+// NewMockHandler creates a new mock instance.
+func NewMockHandler(ctrl *gomock.Controller) *MockHandler {
+	mock := &MockHandler{ctrl: ctrl}
+	mock.recorder = &MockHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHandler) EXPECT() *MockHandlerMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockHandler) Add(arg0 handler.Handler, arg1 ...handler.Handler) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0}
+	for _, a := range arg1 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Add", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockHandlerMockRecorder) Add(arg0 interface{}, arg1 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0}, arg1...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockHandler)(nil).Add), varargs...)
+}
+
+// IsCritical mocks base method.
+func (m *MockHandler) IsCritical() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsCritical")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsCritical indicates an expected call of IsCritical.
+func (mr *MockHandlerMockRecorder) IsCritical() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsCritical", reflect.TypeOf((*MockHandler)(nil).IsCritical))
+}
+
+// Name mocks base method.
+func (m *MockHandler) Name() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Name")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Name indicates an expected call of Name.
+func (mr *MockHandlerMockRecorder) Name() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Name", reflect.TypeOf((*MockHandler)(nil).Name))
+}
+
+// NotifyEarlyExit mocks base method.
+func (m *MockHandler) NotifyEarlyExit(arg0 chan<- error) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "NotifyEarlyExit", arg0)
+}
+
+// NotifyEarlyExit indicates an expected call of NotifyEarlyExit.
+func (mr *MockHandlerMockRecorder) NotifyEarlyExit(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyEarlyExit", reflect.TypeOf((*MockHandler)(nil).NotifyEarlyExit), arg0)
+}
+
+// Shutdown mocks base method.
+func (m *MockHandler) Shutdown(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Shutdown", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Shutdown indicates an expected call of Shutdown.
+func (mr *MockHandlerMockRecorder) Shutdown(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Shutdown", reflect.TypeOf((*MockHandler)(nil).Shutdown), arg0)
+}
+
+// ShutdownReport mocks base method.
+func (m *MockHandler) ShutdownReport(arg0 context.Context) (*report.Report, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ShutdownReport", arg0)
+	ret0, _ := ret[0].(*report.Report)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ShutdownReport indicates an expected call of ShutdownReport.
+func (mr *MockHandlerMockRecorder) ShutdownReport(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShutdownReport", reflect.TypeOf((*MockHandler)(nil).ShutdownReport), arg0)
+}
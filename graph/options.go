@@ -0,0 +1,56 @@
+package graph
+
+import (
+	"time"
+
+	"github.com/qdm12/goshutdown/event"
+)
+
+type Option func(s *settings)
+
+// OptionTimeout sets a global timeout for the graph shutdown operation.
+// Note the timeout defaults to one second.
+func OptionTimeout(timeout time.Duration) Option {
+	return func(s *settings) {
+		s.timeout = timeout
+	}
+}
+
+// OptionReadyTimeout sets a timeout for each handler of the graph to be ready
+// in StartAndWaitReady. Note the timeout defaults to ten seconds, and can
+// be set to 0 to disable it.
+func OptionReadyTimeout(timeout time.Duration) Option {
+	return func(s *settings) {
+		s.readyTimeout = timeout
+	}
+}
+
+// OptionCritical marks the shutdown operation as critical.
+func OptionCritical() Option {
+	return func(s *settings) {
+		s.critical = true
+	}
+}
+
+// OptionOnSuccess sets a function to execute when the shutdown is a success.
+func OptionOnSuccess(fn func(name string)) Option {
+	return func(s *settings) {
+		s.onSuccess = fn
+	}
+}
+
+// OptionOnFailure sets a function to execute when the shutdown is a failure.
+func OptionOnFailure(fn func(name string, err error)) Option {
+	return func(s *settings) {
+		s.onFailure = fn
+	}
+}
+
+// OptionObserver adds an observer of the shutdown events of the graph and
+// of its nested handlers. Nested handlers also deliver their events to the
+// observers of their parent handlers.
+func OptionObserver(observer event.Observer) Option {
+	return func(s *settings) {
+		s.observers = append(s.observers, observer)
+	}
+}
@@ -0,0 +1,43 @@
+package graph
+
+import (
+	"time"
+
+	"github.com/qdm12/goshutdown/event"
+)
+
+// settings defines configuration settings for the shutdown Graph.
+type settings struct {
+	// timeout is the global timeout for all shutdown operations.
+	// It defaults to 1s if left unset.
+	timeout time.Duration
+	// critical can be set to true to indicate the shutdown process should exit if
+	// this graph of shutdown handlers cannot be completed.
+	critical bool
+	// onSuccess defines a function to execute when an handler in the graph
+	// terminates successfully. It is disabled if it is left unset.
+	onSuccess func(name string)
+	// onFailure defines a function to execute when an handler in the graph
+	// does not terminate on time. It is disabled if it is left unset.
+	onFailure func(name string, err error)
+	// readyTimeout is the timeout for each handler of the graph to be ready
+	// in StartAndWaitReady. It defaults to 10s if left unset, and no timeout
+	// is applied if it is set to 0.
+	readyTimeout time.Duration
+	// observers are the observers of the shutdown events of the graph
+	// and of its nested handlers, in addition to the observers of the
+	// parent handlers. It is disabled if it is left unset.
+	observers []event.Observer
+}
+
+func newSettings() settings {
+	return settings{
+		timeout:      time.Second,
+		onSuccess:    defaultOnSuccess,
+		onFailure:    defaultOnFailure,
+		readyTimeout: 10 * time.Second,
+	}
+}
+
+func defaultOnSuccess(name string)            {}
+func defaultOnFailure(name string, err error) {}
@@ -0,0 +1,95 @@
+package group
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/qdm12/goshutdown/handler"
+	"github.com/qdm12/goshutdown/internal/results"
+)
+
+// ShutdownError is the error returned by Shutdown when one or
+// more handlers of the group failed to shutdown.
+type ShutdownError struct {
+	// Name is the name of the group handler.
+	Name string
+	// Results are the shutdown results of the hooks and handlers of the
+	// group, in the order they completed. Handlers still running when the
+	// group timeout elapsed have an ErrStillRunning error, and come after
+	// the other handlers.
+	Results []handler.Result
+	// CriticalFailure is the result of the first critical handler which
+	// failed and canceled the shutdown of the other handlers, and is nil
+	// if there is none.
+	CriticalFailure *handler.Result
+}
+
+func (e *ShutdownError) Error() string {
+	var handlers int
+	var handlerMessages, hookMessages []string
+	for _, result := range e.Results {
+		switch {
+		case result.Hook && result.Err != nil:
+			// hook errors already contain the hook phase and name
+			hookMessages = append(hookMessages, result.Err.Error())
+		case result.Hook:
+		case result.Err != nil:
+			handlers++
+			handlerMessages = append(handlerMessages, result.Name+": "+result.Err.Error())
+		default:
+			handlers++
+		}
+	}
+
+	var message string
+	switch {
+	case e.CriticalFailure != nil:
+		message = ErrCriticalTimeout.Error() + ": " + e.CriticalFailure.Err.Error()
+	case len(handlerMessages) > 0:
+		message = fmt.Sprintf("%s: %d out of %d goroutines: %s",
+			ErrTimeout, len(handlerMessages), handlers,
+			strings.Join(handlerMessages, ", "))
+	default:
+		message = ErrTimeout.Error()
+	}
+
+	stillRunning := e.StillRunning()
+	if len(stillRunning) > 0 {
+		message += " (still running: " + strings.Join(stillRunning, ", ") + ")"
+	}
+
+	if len(hookMessages) > 0 {
+		message += "; hooks: " + strings.Join(hookMessages, ", ")
+	}
+	return message
+}
+
+// StillRunning returns the names of the handlers still
+// running when the group timeout elapsed.
+func (e *ShutdownError) StillRunning() (names []string) {
+	for _, result := range e.Results {
+		if errors.Is(result.Err, ErrStillRunning) {
+			names = append(names, result.Name)
+		}
+	}
+	return names
+}
+
+// Failed returns the results of the handlers which failed to shutdown.
+func (e *ShutdownError) Failed() (failed []handler.Result) {
+	return results.Failed(e.Results)
+}
+
+// Is returns true if the target is ErrCriticalTimeout for a group
+// with a critical failure, ErrTimeout otherwise, or if the target
+// matches one of the errors of the failed handlers.
+func (e *ShutdownError) Is(target error) bool {
+	return results.Is(e.Results, e.CriticalFailure, target, ErrCriticalTimeout, ErrTimeout)
+}
+
+// As finds the first error of the failed handlers matching the target,
+// and if so sets the target to that error value and returns true.
+func (e *ShutdownError) As(target interface{}) bool {
+	return results.As(e.Results, target)
+}
@@ -0,0 +1,133 @@
+package group
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/qdm12/goshutdown/goroutine"
+	"github.com/qdm12/goshutdown/handler"
+	"github.com/qdm12/goshutdown/hook"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ShutdownError(t *testing.T) {
+	t.Parallel()
+
+	exitErr := &goroutine.ExitError{Err: errors.New("flush failed")}
+
+	testCases := map[string]struct {
+		err        *ShutdownError
+		message    string
+		isTimeout  bool
+		isCritical bool
+	}{
+		"non critical failures": {
+			err: &ShutdownError{
+				Name: "group",
+				Results: []handler.Result{
+					{Name: "A"},
+					{Name: "B", Err: exitErr},
+					{Name: "C", Err: goroutine.ErrTimeout},
+				},
+			},
+			message: "group shutdown timed out: 2 out of 3 goroutines: " +
+				"B: goroutine exited with error: flush failed, C: goroutine shutdown timed out",
+			isTimeout: true,
+		},
+		"critical failure": {
+			err: &ShutdownError{
+				Name: "group",
+				Results: []handler.Result{
+					{Name: "B", Critical: true, Err: exitErr},
+					{Name: "C", Err: goroutine.ErrTimeout},
+				},
+				CriticalFailure: &handler.Result{Name: "B", Critical: true, Err: exitErr},
+			},
+			message:    "critical shutdown timed out in the group: goroutine exited with error: flush failed",
+			isCritical: true,
+		},
+		"hooks not counted": {
+			err: &ShutdownError{
+				Name: "group",
+				Results: []handler.Result{
+					{Name: "flush metrics", Hook: true},
+					{Name: "A"},
+					{Name: "B", Err: exitErr},
+					{Name: "final log", Hook: true, Err: &hook.Error{
+						Name: "final log", Phase: hook.PhaseAfter, Err: goroutine.ErrTimeout}},
+				},
+			},
+			message: "group shutdown timed out: 1 out of 2 goroutines: " +
+				"B: goroutine exited with error: flush failed; " +
+				`hooks: after hook "final log" failed: goroutine shutdown timed out`,
+			isTimeout: true,
+		},
+		"only hooks failed": {
+			err: &ShutdownError{
+				Name: "group",
+				Results: []handler.Result{
+					{Name: "A"},
+					{Name: "B"},
+					{Name: "flush metrics", Hook: true, Err: &hook.Error{
+						Name: "flush metrics", Phase: hook.PhaseBefore, Err: exitErr}},
+					{Name: "final log", Hook: true, Err: &hook.Error{
+						Name: "final log", Phase: hook.PhaseAfter, Err: goroutine.ErrTimeout}},
+				},
+			},
+			message: "group shutdown timed out; " +
+				`hooks: before hook "flush metrics" failed: goroutine exited with error: flush failed, ` +
+				`after hook "final log" failed: goroutine shutdown timed out`,
+			isTimeout: true,
+		},
+		"still running": {
+			err: &ShutdownError{
+				Name: "group",
+				Results: []handler.Result{
+					{Name: "A", Err: exitErr},
+					{Name: "B", Err: goroutine.ErrTimeout},
+					{Name: "C", Err: fmt.Errorf("%w: after group timeout of 1s", ErrStillRunning)},
+				},
+			},
+			message: "group shutdown timed out: 3 out of 3 goroutines: " +
+				"A: goroutine exited with error: flush failed, B: goroutine shutdown timed out, " +
+				"C: still running: after group timeout of 1s (still running: C)",
+			isTimeout: true,
+		},
+		"critical still running": {
+			err: &ShutdownError{
+				Name: "group",
+				Results: []handler.Result{
+					{Name: "A", Err: exitErr},
+					{Name: "B", Critical: true, Err: fmt.Errorf("%w: after group timeout of 1s", ErrStillRunning)},
+					{Name: "C", Err: fmt.Errorf("%w: after group timeout of 1s", ErrStillRunning)},
+					{Name: "D", Err: goroutine.ErrTimeout},
+				},
+				CriticalFailure: &handler.Result{Name: "B", Critical: true,
+					Err: fmt.Errorf("%w: after group timeout of 1s", ErrStillRunning)},
+			},
+			message: "critical shutdown timed out in the group: " +
+				"still running: after group timeout of 1s (still running: B, C)",
+			isCritical: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := testCase.err
+
+			assert.Equal(t, testCase.message, err.Error())
+			assert.Equal(t, testCase.isTimeout, errors.Is(err, ErrTimeout))
+			assert.Equal(t, testCase.isCritical, errors.Is(err, ErrCriticalTimeout))
+			assert.True(t, errors.Is(err, goroutine.ErrTimeout))
+
+			var target *goroutine.ExitError
+			require.True(t, errors.As(err, &target))
+			assert.Equal(t, exitErr, target)
+		})
+	}
+}
@@ -4,15 +4,21 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"strings"
+	"sync"
+	"time"
 
-	"github.com/qdm12/goshutdown/goroutine"
+	"github.com/qdm12/goshutdown/event"
 	"github.com/qdm12/goshutdown/handler"
+	"github.com/qdm12/goshutdown/hook"
+	"github.com/qdm12/goshutdown/internal/ready"
+	"github.com/qdm12/goshutdown/report"
 )
 
 //go:generate mockgen -destination=mock_$GOPACKAGE/$GOFILE . Handler
 
 // Handler handles a group of shutdown handlers.
+// The handler created by New also implements handler.EarlyExitNotifier,
+// report.Reporter, handler.ReadyStarter, handler.Parent and handler.Timeouter.
 type Handler interface {
 	// Name returns the name set for this group handler.
 	Name() string
@@ -21,7 +27,12 @@ type Handler interface {
 	IsCritical() bool
 	// Shutdown initiates the shutdown process for all the goroutines of the group in parallel.
 	// It executes onSuccess or onFailure if a goroutine completion is a success or a failure, respectively.
-	// It returns the number of incomplete goroutines when done.
+	// It returns a *ShutdownError if one or more goroutines did not complete on time,
+	// and nil otherwise. The before hooks run first, and the after hooks run last,
+	// even if a critical handler failed to shutdown. The before hooks share the timeout
+	// of the group, and the after hooks have their own timeout so they can run after
+	// the group timed out. A hook failure is reported as a non critical failure in the
+	// *ShutdownError.
 	Shutdown(ctx context.Context) (err error)
 	// Add adds a goroutine to the group of goroutine handlers.
 	Add(handlers ...handler.Handler)
@@ -31,6 +42,8 @@ type groupHandler struct {
 	name     string
 	settings Settings
 	handlers []handler.Handler
+	// earlyExitChannels are the channels registered with NotifyEarlyExit.
+	earlyExitChannels []chan<- error
 }
 
 func New(name string, options ...Option) Handler {
@@ -53,68 +66,239 @@ func (h *groupHandler) IsCritical() bool {
 	return h.settings.Critical
 }
 
+// Children returns a copy of the handlers of the group,
+// in the order they were added.
+func (h *groupHandler) Children() []handler.Handler {
+	children := make([]handler.Handler, len(h.handlers))
+	copy(children, h.handlers)
+	return children
+}
+
+// Timeout returns the timeout of the group, where 0 indicates no timeout.
+func (h *groupHandler) Timeout() time.Duration {
+	return h.settings.Timeout
+}
+
 func (h *groupHandler) Add(handlers ...handler.Handler) {
+	for _, ch := range h.earlyExitChannels {
+		notifyEarlyExit(handlers, ch)
+	}
 	h.handlers = append(h.handlers, handlers...)
 }
 
+// NotifyEarlyExit registers the channel given to receive a
+// *goroutine.EarlyExitError for each goroutine of the handlers
+// terminating before its shutdown is initiated. This applies
+// recursively to all the handlers implementing the
+// handler.EarlyExitNotifier interface, including the ones added
+// after this call.
+func (h *groupHandler) NotifyEarlyExit(ch chan<- error) {
+	h.earlyExitChannels = append(h.earlyExitChannels, ch)
+	notifyEarlyExit(h.handlers, ch)
+}
+
+func notifyEarlyExit(handlers []handler.Handler, ch chan<- error) {
+	for _, h := range handlers {
+		notifier, ok := h.(handler.EarlyExitNotifier)
+		if ok {
+			notifier.NotifyEarlyExit(ch)
+		}
+	}
+}
+
 var (
 	// ErrCriticalTimeout is the error when a critical goroutine shutdown timed out in the group.
 	ErrCriticalTimeout = errors.New("critical shutdown timed out in the group")
 	// ErrTimeout is the error when one of the group shutdown times out.
 	ErrTimeout = errors.New("group shutdown timed out")
+	// ErrStillRunning is the error for an handler still running when the group timeout elapses.
+	ErrStillRunning = errors.New("still running")
+	// ErrReadyTimeout is the error when a handler of the group is not ready
+	// within the ready timeout of the group.
+	ErrReadyTimeout = errors.New("readiness timed out")
 )
 
+// StartAndWaitReady starts the handlers of the group implementing the
+// handler.ReadyStarter interface, such as goroutine.ReadyHandler, in
+// parallel, and waits for all of them to be ready. Each handler is given
+// the ready timeout of the group to be ready. It returns an error for the
+// first handler, in the order they were added, which failed to be ready.
+func (h *groupHandler) StartAndWaitReady(ctx context.Context) (err error) {
+	errs := make([]error, len(h.handlers))
+	var wg sync.WaitGroup
+	for i, child := range h.handlers {
+		starter, ok := child.(handler.ReadyStarter)
+		if !ok {
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, starter handler.ReadyStarter) {
+			defer wg.Done()
+			errs[i] = ready.StartAndWait(ctx, starter, h.settings.ReadyTimeout, ErrReadyTimeout)
+		}(i, starter)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			return fmt.Errorf("%s: %w", h.handlers[i].Name(), err)
+		}
+	}
+	return nil
+}
+
 func (h *groupHandler) Shutdown(ctx context.Context) (err error) {
+	_, err = h.ShutdownReport(ctx)
+	return err
+}
+
+// ShutdownReport shuts down the group as Shutdown does, and returns
+// the shutdown report of the group and of its nested handlers.
+func (h *groupHandler) ShutdownReport(ctx context.Context) (
+	rep *report.Report, err error) {
+	start := time.Now()
+	rep = &report.Report{
+		Name:     h.name,
+		Kind:     report.KindGroup,
+		Critical: h.settings.Critical,
+		Children: make([]*report.Report, 0,
+			len(h.settings.BeforeHooks)+len(h.handlers)+len(h.settings.AfterHooks)),
+	}
+	ctx, events := event.NewScope(ctx, h.name, h.settings.Critical, h.settings.Observers...)
+	events.ShutdownStarted()
+	defer func() {
+		rep.Duration = time.Since(start)
+		rep.SetError(err)
+		events.ShutdownFinished(rep, err)
+	}()
+
+	// hooksParentCtx is not canceled on a critical failure,
+	// so the after hooks can run.
+	hooksParentCtx := ctx
+
+	beforeHooksCtx, beforeHooksCancel := ctx, context.CancelFunc(func() {})
+	if h.settings.Timeout > 0 {
+		beforeHooksCtx, beforeHooksCancel = context.WithDeadline(ctx, start.Add(h.settings.Timeout))
+	}
+	defer beforeHooksCancel()
+
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
+	results := make([]handler.Result, 0,
+		len(h.settings.BeforeHooks)+len(h.handlers)+len(h.settings.AfterHooks))
+	var criticalFailure *handler.Result
+	failed := false
+	handleResult := func(id uint64, childReport *report.Report, childErr error) {
+		events.HandlerFinished(id, childReport, childErr)
+		rep.Children = append(rep.Children, childReport)
+		result := childReport.Result(childErr)
+		results = append(results, result)
+		if childErr == nil {
+			h.settings.OnSuccess(result.Name)
+			return
+		}
+
+		failed = true
+		h.settings.OnFailure(result.Name, result.Err)
+
+		if criticalFailure == nil && result.Critical {
+			criticalFailure = &result
+			events.CriticalAbort(id, result.Name, result.Err)
+			cancel() // stop shutdown of other goroutines
+		}
+	}
+	runHook := func(ctx context.Context, phase hook.Phase, hookToRun hook.Hook) {
+		hookCtx, id := events.HandlerStarted(ctx, hookToRun.Name, false)
+		hookReport, err := hook.Run(hookCtx, phase, hookToRun)
+		handleResult(id, hookReport, err)
+	}
+
+	for _, beforeHook := range h.settings.BeforeHooks {
+		runHook(beforeHooksCtx, hook.PhaseBefore, beforeHook)
+	}
+
+	// the timer is started after the before hooks, with the remaining time,
+	// and timeoutCh is nil and never receives if there is no timeout.
+	var timeoutCh <-chan time.Time
+	if h.settings.Timeout > 0 {
+		timer := time.NewTimer(h.settings.Timeout - time.Since(start))
+		defer timer.Stop()
+		timeoutCh = timer.C
+	}
+
 	type completionStatus struct {
-		name     string
-		critical bool
-		err      error
+		index  int
+		report *report.Report
+		err    error
 	}
-	completed := make(chan completionStatus)
+	completed := make(chan completionStatus, len(h.handlers))
 
-	for _, handler := range h.handlers {
-		go func(handler goroutine.Handler) {
+	pending := make([]*report.Report, len(h.handlers))
+	ids := make([]uint64, len(h.handlers))
+	for i, child := range h.handlers {
+		name, critical := child.Name(), child.IsCritical()
+		pending[i] = &report.Report{
+			Name:     name,
+			Kind:     report.KindHandler,
+			Critical: critical,
+		}
+		childCtx, id := events.HandlerStarted(ctx, name, critical)
+		ids[i] = id
+		go func(ctx context.Context, index int, name string, critical bool) {
+			childReport, err := report.Shutdown(ctx, h.handlers[index], name, critical)
 			completed <- completionStatus{
-				name:     handler.Name(),
-				critical: handler.IsCritical(),
-				err:      handler.Shutdown(ctx),
+				index:  index,
+				report: childReport,
+				err:    err,
 			}
-		}(handler)
+		}(childCtx, i, name, critical)
 	}
 
-	var criticalErr error
-	var errorMessages []string
-	for range h.handlers {
-		status := <-completed
-		if status.err == nil {
-			h.settings.OnSuccess(status.name)
-			continue
+	timedOut := false
+	for remaining := len(h.handlers); remaining > 0 && !timedOut; remaining-- {
+		select {
+		case status := <-completed:
+			pending[status.index] = nil
+			handleResult(ids[status.index], status.report, status.err)
+		case <-timeoutCh:
+			timedOut = true
+			cancel() // stop shutdown of still running goroutines
 		}
+	}
 
-		h.settings.OnFailure(status.name, status.err)
-
-		if criticalErr == nil && status.critical {
-			criticalErr = status.err
-			cancel() // stop shutdown of other goroutines
+	if timedOut {
+		duration := time.Since(start)
+		for i, childReport := range pending {
+			if childReport == nil {
+				continue
+			}
+			childErr := fmt.Errorf("%w: after group timeout of %s", ErrStillRunning, h.settings.Timeout)
+			childReport.Duration = duration
+			childReport.Status = report.StatusTimeout
+			childReport.Error = childErr.Error()
+			handleResult(ids[i], childReport, childErr)
 		}
+	}
 
-		if criticalErr == nil {
-			errorMessages = append(errorMessages, status.name+": "+status.err.Error())
-		}
+	afterHooksCtx, afterHooksCancel := hooksParentCtx, context.CancelFunc(func() {})
+	if h.settings.AfterHooksTimeout > 0 {
+		afterHooksCtx, afterHooksCancel = context.WithTimeout(hooksParentCtx, h.settings.AfterHooksTimeout)
 	}
+	defer afterHooksCancel()
 
-	if criticalErr != nil {
-		return fmt.Errorf("%w: %s", ErrCriticalTimeout, criticalErr)
+	for _, afterHook := range h.settings.AfterHooks {
+		runHook(afterHooksCtx, hook.PhaseAfter, afterHook)
 	}
 
-	if len(errorMessages) == 0 {
-		return nil
+	if !failed {
+		return rep, nil
 	}
 
-	return fmt.Errorf("%w: %d out of %d goroutines: %s",
-		ErrTimeout, len(errorMessages), len(h.handlers),
-		strings.Join(errorMessages, ", "))
+	return rep, &ShutdownError{
+		Name:            h.name,
+		Results:         results,
+		CriticalFailure: criticalFailure,
+	}
 }
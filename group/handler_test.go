@@ -23,9 +23,11 @@ func Test_New(t *testing.T) {
 	expected := &groupHandler{
 		name: name,
 		settings: Settings{
-			Timeout:   time.Hour,
-			OnSuccess: defaultOnSuccess,
-			OnFailure: defaultOnFailure,
+			Timeout:           time.Hour,
+			OnSuccess:         defaultOnSuccess,
+			OnFailure:         defaultOnFailure,
+			ReadyTimeout:      10 * time.Second,
+			AfterHooksTimeout: time.Second,
 		},
 	}
 
@@ -63,6 +65,37 @@ func Test_groupHandler_IsCritical(t *testing.T) {
 	assert.Equal(t, critical, c)
 }
 
+func Test_groupHandler_Children(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	a := mock_handler.NewMockHandler(ctrl)
+	b := mock_handler.NewMockHandler(ctrl)
+	h := &groupHandler{
+		handlers: []handler.Handler{a, b},
+	}
+
+	children := h.Children()
+
+	assert.Equal(t, []handler.Handler{a, b}, children)
+
+	// Modifying the children does not modify the group
+	children[0] = nil
+	assert.Equal(t, []handler.Handler{a, b}, h.handlers)
+}
+
+func Test_groupHandler_Timeout(t *testing.T) {
+	t.Parallel()
+	const timeout = time.Second
+
+	h := &groupHandler{
+		settings: Settings{Timeout: timeout},
+	}
+	d := h.Timeout()
+
+	assert.Equal(t, timeout, d)
+}
+
 func Test_groupHandler_Add(t *testing.T) {
 	t.Parallel()
 	ctrl := gomock.NewController(t)
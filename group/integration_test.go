@@ -0,0 +1,230 @@
+package group
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/qdm12/goshutdown/goroutine"
+	"github.com/qdm12/goshutdown/handler"
+	"github.com/qdm12/goshutdown/report"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func functionA(ctx context.Context, done chan<- struct{}) {
+	<-ctx.Done()
+	close(done)
+}
+
+func functionB(ctx context.Context, _ chan<- struct{}) {
+	<-ctx.Done()
+}
+
+func Test_Handler_NoHandlers(t *testing.T) {
+	t.Parallel()
+	group := New("group")
+	err := group.Shutdown(context.Background())
+	require.NoError(t, err)
+}
+
+func Test_Handler_GoRoutines_AllComplete(t *testing.T) {
+	t.Parallel()
+	group := New("group", OptionTimeout(time.Hour))
+
+	handlerA, ctxA, doneA := goroutine.New("A")
+	go functionA(ctxA, doneA)
+	group.Add(handlerA)
+
+	handlerB, ctxB, doneB := goroutine.New("B")
+	go functionA(ctxB, doneB)
+	group.Add(handlerB)
+
+	err := group.Shutdown(context.Background())
+	require.NoError(t, err)
+}
+
+func Test_Handler_GoRoutines_GroupTimeout(t *testing.T) {
+	t.Parallel()
+	group := New("group", OptionTimeout(100*time.Millisecond))
+
+	handlerA, ctxA, doneA := goroutine.New("A", goroutine.OptionTimeout(time.Hour))
+	go functionA(ctxA, doneA)
+	group.Add(handlerA)
+
+	handlerB, ctxB, doneB := goroutine.New("B", goroutine.OptionTimeout(time.Hour))
+	go functionB(ctxB, doneB)
+	group.Add(handlerB)
+
+	err := group.Shutdown(context.Background())
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrTimeout)
+	assert.Equal(t, "group shutdown timed out: 1 out of 2 goroutines: "+
+		"B: still running: after group timeout of 100ms (still running: B)", err.Error())
+}
+
+func Test_Handler_GoRoutines_NoGroupTimeout(t *testing.T) {
+	t.Parallel()
+	group := New("group", OptionTimeout(0))
+
+	handlerA, ctxA, doneA := goroutine.New("A", goroutine.OptionTimeout(time.Hour))
+	go func() {
+		<-ctxA.Done()
+		time.Sleep(10 * time.Millisecond)
+		close(doneA)
+	}()
+	group.Add(handlerA)
+
+	err := group.Shutdown(context.Background())
+	require.NoError(t, err)
+}
+
+func Test_Handler_GoRoutines_GroupTimeoutCritical(t *testing.T) {
+	t.Parallel()
+	group := New("group", OptionTimeout(100*time.Millisecond))
+
+	handlerA, ctxA, doneA := goroutine.New("A", goroutine.OptionTimeout(time.Hour))
+	go functionA(ctxA, doneA)
+	group.Add(handlerA)
+
+	handlerB, ctxB, doneB := goroutine.New("B", goroutine.OptionTimeout(time.Hour), goroutine.OptionCritical())
+	go functionB(ctxB, doneB)
+	group.Add(handlerB)
+
+	err := group.Shutdown(context.Background())
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrCriticalTimeout)
+	assert.Equal(t, "critical shutdown timed out in the group: "+
+		"still running: after group timeout of 100ms (still running: B)", err.Error())
+}
+
+func Test_Handler_GoRoutines_GoRoutineTimeout(t *testing.T) {
+	t.Parallel()
+	group := New("group", OptionTimeout(time.Hour))
+
+	handlerA, ctxA, doneA := goroutine.New("A")
+	go functionA(ctxA, doneA)
+	group.Add(handlerA)
+
+	handlerB, ctxB, doneB := goroutine.New("B", goroutine.OptionTimeout(time.Nanosecond))
+	go functionB(ctxB, doneB)
+	group.Add(handlerB)
+
+	err := group.Shutdown(context.Background())
+	require.Error(t, err)
+	assert.Equal(t, "group shutdown timed out: 1 out of 2 goroutines: B: goroutine shutdown timed out: after 1ns", err.Error()) //nolint:lll
+}
+
+func Test_Handler_Hooks(t *testing.T) {
+	t.Parallel()
+
+	var calls []string
+	record := func(name string) func(ctx context.Context) error {
+		return func(ctx context.Context) error {
+			calls = append(calls, name)
+			return ctx.Err()
+		}
+	}
+
+	group := New("group", OptionTimeout(100*time.Millisecond),
+		OptionBeforeHook("flush metrics", record("flush metrics")),
+		OptionAfterHook("final log", record("final log")))
+
+	handlerA, ctxA, doneA := goroutine.New("A",
+		goroutine.OptionTimeout(time.Nanosecond), goroutine.OptionCritical())
+	go functionB(ctxA, doneA)
+	group.Add(handlerA)
+
+	rep, err := group.(report.Reporter).ShutdownReport(context.Background())
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrCriticalTimeout)
+	assert.Equal(t, []string{"flush metrics", "final log"}, calls)
+
+	require.Len(t, rep.Children, 3)
+	assert.Equal(t, "flush metrics", rep.Children[0].Name)
+	assert.Equal(t, report.StatusSuccess, rep.Children[0].Status)
+	assert.Equal(t, "A", rep.Children[1].Name)
+	// the after hook context is not canceled by the critical failure
+	assert.Equal(t, "final log", rep.Children[2].Name)
+	assert.Equal(t, report.StatusSuccess, rep.Children[2].Status)
+}
+
+func Test_Handler_AfterHooksAfterGroupTimeout(t *testing.T) {
+	t.Parallel()
+
+	group := New("group", OptionTimeout(100*time.Millisecond),
+		OptionAfterHook("final log", func(ctx context.Context) error {
+			return ctx.Err()
+		}),
+		OptionAfterHook("slow final log", func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}),
+		OptionAfterHooksTimeout(100*time.Millisecond))
+
+	handlerA, ctxA, doneA := goroutine.New("A", goroutine.OptionTimeout(time.Hour))
+	go functionB(ctxA, doneA)
+	group.Add(handlerA)
+
+	err := group.Shutdown(context.Background())
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrTimeout)
+	// the after hooks are not given the expired context of the group
+	assert.Equal(t, "group shutdown timed out: 1 out of 1 goroutines: "+
+		"A: still running: after group timeout of 100ms (still running: A); "+
+		`hooks: after hook "slow final log" failed: context deadline exceeded`, err.Error())
+}
+
+func Test_Handler_StartAndWaitReady(t *testing.T) {
+	t.Parallel()
+
+	t.Run("ready in parallel", func(t *testing.T) {
+		t.Parallel()
+
+		// server is only ready once cache is started, which
+		// requires both to be started in parallel.
+		cacheStarted := make(chan struct{})
+		server := goroutine.NewReady("server", func(ctx context.Context, ready chan<- struct{}) error {
+			<-cacheStarted
+			close(ready)
+			<-ctx.Done()
+			return nil
+		})
+		cache := goroutine.NewReady("cache", func(ctx context.Context, ready chan<- struct{}) error {
+			close(cacheStarted)
+			close(ready)
+			<-ctx.Done()
+			return nil
+		})
+
+		group := New("group")
+		handlerA, ctxA, doneA := goroutine.New("A")
+		go functionA(ctxA, doneA)
+		group.Add(server, handlerA, cache)
+
+		err := group.(handler.ReadyStarter).StartAndWaitReady(context.Background())
+		require.NoError(t, err)
+
+		err = group.Shutdown(context.Background())
+		require.NoError(t, err)
+	})
+
+	t.Run("not ready", func(t *testing.T) {
+		t.Parallel()
+
+		group := New("group", OptionReadyTimeout(time.Millisecond))
+		group.Add(goroutine.NewReady("server",
+			func(ctx context.Context, ready chan<- struct{}) error {
+				<-ctx.Done()
+				return nil
+			}))
+
+		err := group.(handler.ReadyStarter).StartAndWaitReady(context.Background())
+		require.Error(t, err)
+		assert.ErrorIs(t, err, ErrReadyTimeout)
+		assert.EqualError(t, err, "server: readiness timed out: after 1ms")
+
+		err = group.Shutdown(context.Background())
+		require.NoError(t, err)
+	})
+}
@@ -1,11 +1,17 @@
 package group
 
-import "time"
+import (
+	"context"
+	"time"
+
+	"github.com/qdm12/goshutdown/event"
+	"github.com/qdm12/goshutdown/hook"
+)
 
 type Option func(s *Settings)
 
-// OptionTimeout sets a timeout for the goroutine shutdown operation.
-// Note the timeout defaults to one second.
+// OptionTimeout sets a timeout for the shutdown of all the handlers of the group.
+// Note the timeout defaults to one second, and can be set to 0 to disable it.
 func OptionTimeout(timeout time.Duration) Option {
 	return func(s *Settings) {
 		s.Timeout = timeout
@@ -32,3 +38,48 @@ func OptionOnFailure(fn func(orderName string, err error)) Option {
 		s.OnFailure = fn
 	}
 }
+
+// OptionReadyTimeout sets a timeout for each handler of the group to be ready
+// in StartAndWaitReady. Note the timeout defaults to ten seconds, and can
+// be set to 0 to disable it.
+func OptionReadyTimeout(timeout time.Duration) Option {
+	return func(s *Settings) {
+		s.ReadyTimeout = timeout
+	}
+}
+
+// OptionAfterHooksTimeout sets a timeout for running all the after hooks,
+// starting once the handlers of the group are shut down or timed out.
+// Note the timeout defaults to one second, and can be set to 0 to disable it.
+func OptionAfterHooksTimeout(timeout time.Duration) Option {
+	return func(s *Settings) {
+		s.AfterHooksTimeout = timeout
+	}
+}
+
+// OptionObserver adds an observer of the shutdown events of the group and of
+// its nested order, group and graph handlers. Nested handlers also deliver
+// their events to the observers of their parent handlers.
+func OptionObserver(observer event.Observer) Option {
+	return func(s *Settings) {
+		s.Observers = append(s.Observers, observer)
+	}
+}
+
+// OptionBeforeHook adds a hook to run before shutting down the handlers
+// of the group. Hooks run in the order they are added, share the timeout
+// of the group, and their failures do not stop the shutdown.
+func OptionBeforeHook(name string, fn func(ctx context.Context) error) Option {
+	return func(s *Settings) {
+		s.BeforeHooks = append(s.BeforeHooks, hook.Hook{Name: name, Fn: fn})
+	}
+}
+
+// OptionAfterHook adds a hook to run after shutting down the handlers
+// of the group, even if a critical handler failed to shutdown. Hooks run
+// in the order they are added, and share the after hooks timeout.
+func OptionAfterHook(name string, fn func(ctx context.Context) error) Option {
+	return func(s *Settings) {
+		s.AfterHooks = append(s.AfterHooks, hook.Hook{Name: name, Fn: fn})
+	}
+}
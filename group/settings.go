@@ -1,11 +1,18 @@
 package group
 
-import "time"
+import (
+	"time"
+
+	"github.com/qdm12/goshutdown/event"
+	"github.com/qdm12/goshutdown/hook"
+)
 
 // Settings define configuration settings for the shutdown Group.
 type Settings struct {
 	// Timeout is the timeout for termninating all the goroutines in the group.
-	// It defaults to 1s if left unset.
+	// Handlers still running when the timeout elapses are reported as failed
+	// with ErrStillRunning. It defaults to 1s if left unset, and no timeout is
+	// applied if it is set to 0.
 	Timeout time.Duration
 	// Critical can be set to true to indicate the shutdown process should exit if
 	// this group of goroutines cannot be completed.
@@ -16,13 +23,34 @@ type Settings struct {
 	// OnFailure defines a function to execute when a one of the goroutines
 	// does not terminate on time. It is disabled if it is left unset.
 	OnFailure func(goRoutineName string, err error)
+	// ReadyTimeout is the timeout for each handler of the group to be ready
+	// in StartAndWaitReady. It defaults to 10s if left unset, and no timeout
+	// is applied if it is set to 0.
+	ReadyTimeout time.Duration
+	// BeforeHooks are the hooks to run, in order, before shutting
+	// down the handlers of the group.
+	BeforeHooks []hook.Hook
+	// AfterHooks are the hooks to run, in order, after shutting down
+	// the handlers of the group, even if a critical handler failed.
+	AfterHooks []hook.Hook
+	// AfterHooksTimeout is the timeout for running all the after hooks,
+	// starting once the handlers of the group are shut down or timed out.
+	// It defaults to 1s if left unset, and no timeout is applied if it
+	// is set to 0.
+	AfterHooksTimeout time.Duration
+	// Observers are the observers of the shutdown events of the group
+	// and of its nested handlers, in addition to the observers of the
+	// parent handlers. It is disabled if it is left unset.
+	Observers []event.Observer
 }
 
 func newSettings() Settings {
 	return Settings{
-		Timeout:   time.Second,
-		OnSuccess: defaultOnSuccess,
-		OnFailure: defaultOnFailure,
+		Timeout:           time.Second,
+		OnSuccess:         defaultOnSuccess,
+		OnFailure:         defaultOnFailure,
+		ReadyTimeout:      10 * time.Second,
+		AfterHooksTimeout: time.Second,
 	}
 }
 
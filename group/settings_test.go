@@ -15,9 +15,11 @@ func Test_newSettings(t *testing.T) {
 	s := newSettings()
 
 	expected := Settings{
-		Timeout:   time.Second,
-		OnSuccess: defaultOnSuccess,
-		OnFailure: defaultOnFailure,
+		Timeout:           time.Second,
+		OnSuccess:         defaultOnSuccess,
+		OnFailure:         defaultOnFailure,
+		ReadyTimeout:      10 * time.Second,
+		AfterHooksTimeout: time.Second,
 	}
 
 	var errDummy = errors.New("dummy")
@@ -0,0 +1,11 @@
+package handler
+
+// EarlyExitNotifier is implemented by handlers able to notify when
+// one of their goroutines terminates before its shutdown is initiated.
+type EarlyExitNotifier interface {
+	// NotifyEarlyExit registers the channel given to receive a
+	// *goroutine.EarlyExitError for each goroutine terminating before
+	// its shutdown is initiated. The channel given can notably be the
+	// fatal errors channel given to runner.OptionFatal.
+	NotifyEarlyExit(ch chan<- error)
+}
@@ -0,0 +1,20 @@
+package handler
+
+import "time"
+
+// Parent is implemented by handlers having children handlers,
+// such as an order.Handler or a group.Handler.
+type Parent interface {
+	// Children returns a copy of the children handlers. For handlers
+	// shutting down their children sequentially, the children are in
+	// the order they are shut down.
+	Children() []Handler
+}
+
+// Timeouter is implemented by handlers having a shutdown timeout,
+// such as an order.Handler, a group.Handler or a goroutine.Handler.
+type Timeouter interface {
+	// Timeout returns the shutdown timeout of the handler,
+	// where 0 indicates no timeout.
+	Timeout() time.Duration
+}
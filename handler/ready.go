@@ -0,0 +1,14 @@
+package handler
+
+import "context"
+
+// ReadyStarter is implemented by handlers which can be
+// started and waited on until they are ready, such as a
+// goroutine.ReadyHandler, an order.Handler, a group.Handler
+// or a graph.Handler.
+type ReadyStarter interface {
+	// StartAndWaitReady starts the handler and waits for it to be ready.
+	// It returns an error if the handler fails to become ready or if the
+	// context is done before.
+	StartAndWaitReady(ctx context.Context) (err error)
+}
@@ -0,0 +1,19 @@
+package handler
+
+import "time"
+
+// Result is the shutdown result of a handler.
+type Result struct {
+	// Name is the name of the handler.
+	Name string
+	// Critical is true if the handler is critical.
+	Critical bool
+	// Duration is the time taken to shutdown the handler.
+	Duration time.Duration
+	// Err is the error returned by the handler shutdown,
+	// and is nil if the shutdown succeeded.
+	Err error
+	// Hook is true if the result is for a hook run before
+	// or after the shutdown of the handlers of its parent.
+	Hook bool
+}
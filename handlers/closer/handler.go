@@ -0,0 +1,97 @@
+// Package closer defines shutdown handlers for resources which only
+// need to be closed, such as an io.Closer or a function.
+package closer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+//go:generate mockgen -destination=mock_$GOPACKAGE/$GOFILE . Handler
+
+// Handler handles the closing of a resource.
+type Handler interface {
+	// Name returns the name set for this closer.
+	Name() string
+	// IsCritical returns true if the closer is critical and must be closed.
+	IsCritical() bool
+	// Shutdown closes the resource and returns the closing error.
+	// If the shutdown context is done, it returns the context error.
+	// If the closer specific timeout is reached, it returns a timeout error.
+	Shutdown(ctx context.Context) (err error)
+}
+
+// New creates a closer handler calling the Close method
+// of the io.Closer given on shutdown.
+func New(name string, closer io.Closer, options ...Option) Handler {
+	return NewFunc(name, closer.Close, options...)
+}
+
+// NewFunc creates a closer handler calling the function given on shutdown.
+func NewFunc(name string, fn func() error, options ...Option) Handler {
+	return NewContextFunc(name, func(context.Context) error {
+		return fn()
+	}, options...)
+}
+
+// NewContextFunc creates a closer handler calling the function given
+// on shutdown. The function is given a context canceled when the shutdown
+// context is done or when the closer timeout is reached.
+func NewContextFunc(name string, fn func(ctx context.Context) error,
+	options ...Option) Handler {
+	settings := newSettings()
+	for _, option := range options {
+		option(&settings)
+	}
+
+	return &closerHandler{
+		name:     name,
+		settings: settings,
+		close:    fn,
+	}
+}
+
+type closerHandler struct {
+	name     string
+	settings settings
+	close    func(ctx context.Context) error
+}
+
+func (h *closerHandler) Name() string {
+	return h.name
+}
+
+func (h *closerHandler) IsCritical() bool {
+	return h.settings.critical
+}
+
+// ErrTimeout is the error when the closing times out.
+var ErrTimeout = errors.New("closing timed out")
+
+func (h *closerHandler) Shutdown(ctx context.Context) (err error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	timer := time.NewTimer(h.settings.timeout)
+	if h.settings.timeout == 0 {
+		timer.Stop()
+	}
+	defer timer.Stop()
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- h.close(ctx)
+	}()
+
+	select {
+	case err = <-errCh:
+		return err
+	case <-ctx.Done():
+		return ctx.Err() //nolint:wrapcheck
+	case <-timer.C:
+		return fmt.Errorf("%w: after %s", ErrTimeout, h.settings.timeout)
+	}
+}
@@ -0,0 +1,137 @@
+package closer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testCloser struct {
+	err error
+}
+
+func (c *testCloser) Close() error {
+	return c.err
+}
+
+func Test_New(t *testing.T) {
+	t.Parallel()
+
+	errTest := errors.New("test error")
+
+	intf := New("name", &testCloser{err: errTest},
+		OptionTimeout(time.Hour), OptionCritical())
+
+	impl, ok := intf.(*closerHandler)
+	require.True(t, ok)
+	assert.Equal(t, "name", impl.name)
+	expectedSettings := settings{
+		timeout:  time.Hour,
+		critical: true,
+	}
+	assert.Equal(t, expectedSettings, impl.settings)
+
+	err := intf.Shutdown(context.Background())
+	assert.Equal(t, errTest, err)
+}
+
+func Test_NewFunc(t *testing.T) {
+	t.Parallel()
+
+	called := false
+	h := NewFunc("name", func() error {
+		called = true
+		return nil
+	})
+
+	err := h.Shutdown(context.Background())
+	require.NoError(t, err)
+	assert.True(t, called)
+}
+
+func Test_closerHandler_Name(t *testing.T) {
+	t.Parallel()
+	const name = "name"
+
+	h := &closerHandler{
+		name: name,
+	}
+	n := h.Name()
+
+	assert.Equal(t, name, n)
+}
+
+func Test_closerHandler_IsCritical(t *testing.T) {
+	t.Parallel()
+	const critical = true
+
+	h := &closerHandler{
+		settings: settings{critical: critical},
+	}
+	c := h.IsCritical()
+
+	assert.Equal(t, critical, c)
+}
+
+func Test_closerHandler_Shutdown(t *testing.T) {
+	t.Parallel()
+
+	t.Run("closes", func(t *testing.T) {
+		t.Parallel()
+
+		h := &closerHandler{
+			close:    func(ctx context.Context) error { return nil },
+			settings: settings{timeout: time.Hour},
+		}
+
+		err := h.Shutdown(context.Background())
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("shutdown context canceled", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		block := make(chan struct{})
+		defer close(block)
+		h := &closerHandler{
+			close: func(ctx context.Context) error {
+				<-block
+				return nil
+			},
+			settings: settings{timeout: time.Hour},
+		}
+
+		err := h.Shutdown(ctx)
+
+		require.Error(t, err)
+		assert.Equal(t, context.Canceled, err)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		t.Parallel()
+
+		closeCtxErr := make(chan error, 1)
+		h := &closerHandler{
+			close: func(ctx context.Context) error {
+				<-ctx.Done()
+				closeCtxErr <- ctx.Err()
+				return nil
+			},
+			settings: settings{timeout: time.Nanosecond},
+		}
+
+		err := h.Shutdown(context.Background())
+
+		require.Error(t, err)
+		assert.Equal(t, "closing timed out: after 1ns", err.Error())
+		assert.Equal(t, context.Canceled, <-closeCtxErr)
+	})
+}
@@ -0,0 +1,19 @@
+package closer
+
+import "time"
+
+// settings defines configuration settings for the closer handler.
+type settings struct {
+	// timeout is the timeout for closing.
+	// It defaults to 1s if left unset.
+	timeout time.Duration
+	// critical can be set to true to indicate the shutdown process should exit if
+	// this closer cannot be closed.
+	critical bool
+}
+
+func newSettings() settings {
+	return settings{
+		timeout: time.Second,
+	}
+}
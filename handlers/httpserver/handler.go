@@ -0,0 +1,213 @@
+// Package httpserver defines a shutdown handler for an HTTP server.
+package httpserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+
+	"github.com/qdm12/goshutdown/goroutine"
+)
+
+//go:generate mockgen -destination=mock_$GOPACKAGE/$GOFILE . Handler
+
+// Handler handles the shutdown of an HTTP server.
+type Handler interface {
+	// Name returns the name set for this HTTP server handler.
+	Name() string
+	// IsCritical returns true if the HTTP server handler is critical
+	// and must be terminated before continuing other external shutdown procedures.
+	IsCritical() bool
+	// Start starts listening and serves HTTP requests in a goroutine.
+	// It returns an error if the listener cannot be created.
+	Start() (err error)
+	// Shutdown stops the HTTP server from accepting new connections and
+	// waits for in-flight requests to complete. If the timeout is reached,
+	// the remaining connections are forcibly closed and an error wrapping
+	// ErrTimeout is returned. Calling Shutdown more than once returns
+	// the result of the first call.
+	Shutdown(ctx context.Context) (err error)
+	// ForciblyClosed returns the number of connections forcibly closed
+	// during the last shutdown. It should only be called once Shutdown
+	// has returned.
+	ForciblyClosed() int
+	// NotifyEarlyExit registers the channel given to receive a
+	// *goroutine.EarlyExitError if the HTTP server stops serving
+	// before Shutdown is called. It must be called after Start.
+	NotifyEarlyExit(ch chan<- error)
+}
+
+type httpServerHandler struct {
+	name     string
+	settings settings
+	server   *http.Server
+	started  bool
+
+	// connections are the connections of the server, tracked
+	// using the ConnState hook of the server.
+	connections      map[net.Conn]struct{}
+	connectionsMutex sync.Mutex
+	forciblyClosed   int
+
+	// shutdownStarted is closed when Shutdown is called.
+	shutdownStarted chan struct{}
+	// shutdownOnce ensures the shutdown is only done once,
+	// with shutdownErr its result returned by each Shutdown call.
+	shutdownOnce sync.Once
+	shutdownErr  error
+	// serveDone is closed once the server stops serving,
+	// after serveErr is set.
+	serveDone chan struct{}
+	serveErr  error
+}
+
+// New creates a new HTTP server handler for the server given.
+// Note it wraps the ConnState hook of the server to track its connections.
+func New(name string, server *http.Server, options ...Option) Handler {
+	settings := newSettings()
+	for _, option := range options {
+		option(&settings)
+	}
+
+	h := &httpServerHandler{
+		name:            name,
+		settings:        settings,
+		server:          server,
+		connections:     make(map[net.Conn]struct{}),
+		shutdownStarted: make(chan struct{}),
+		serveDone:       make(chan struct{}),
+	}
+
+	connState := server.ConnState
+	server.ConnState = func(conn net.Conn, state http.ConnState) {
+		h.trackConnection(conn, state)
+		if connState != nil {
+			connState(conn, state)
+		}
+	}
+
+	return h
+}
+
+func (h *httpServerHandler) Name() string {
+	return h.name
+}
+
+func (h *httpServerHandler) IsCritical() bool {
+	return h.settings.critical
+}
+
+func (h *httpServerHandler) Start() (err error) {
+	listener := h.settings.listener
+	if listener == nil {
+		address := h.server.Addr
+		if address == "" {
+			address = ":http"
+		}
+		listener, err = net.Listen("tcp", address)
+		if err != nil {
+			return fmt.Errorf("listening: %w", err)
+		}
+	}
+
+	h.started = true
+	go func() {
+		defer close(h.serveDone)
+		err := h.server.Serve(listener)
+		if !errors.Is(err, http.ErrServerClosed) {
+			h.serveErr = err
+		}
+	}()
+
+	return nil
+}
+
+// ErrTimeout is the error when the HTTP server shutdown times out.
+var ErrTimeout = errors.New("http server shutdown timed out")
+
+func (h *httpServerHandler) Shutdown(ctx context.Context) (err error) {
+	h.shutdownOnce.Do(func() {
+		h.shutdownErr = h.shutdown(ctx)
+	})
+	return h.shutdownErr
+}
+
+func (h *httpServerHandler) shutdown(ctx context.Context) (err error) {
+	close(h.shutdownStarted)
+
+	shutdownCtx := ctx
+	if h.settings.timeout > 0 {
+		var cancel context.CancelFunc
+		shutdownCtx, cancel = context.WithTimeout(ctx, h.settings.timeout)
+		defer cancel()
+	}
+
+	err = h.server.Shutdown(shutdownCtx)
+	if err == nil {
+		if !h.started {
+			return nil
+		}
+		<-h.serveDone
+		return h.serveErr
+	}
+
+	h.connectionsMutex.Lock()
+	h.forciblyClosed = len(h.connections)
+	h.connectionsMutex.Unlock()
+
+	closeErr := h.server.Close()
+	if closeErr != nil {
+		return fmt.Errorf("closing server: %w", closeErr)
+	}
+
+	if ctx.Err() != nil {
+		return ctx.Err() //nolint:wrapcheck
+	}
+
+	return fmt.Errorf("%w: after %s: %d connections forcibly closed",
+		ErrTimeout, h.settings.timeout, h.forciblyClosed)
+}
+
+func (h *httpServerHandler) ForciblyClosed() int {
+	return h.forciblyClosed
+}
+
+func (h *httpServerHandler) NotifyEarlyExit(ch chan<- error) {
+	go func() {
+		select {
+		case <-h.shutdownStarted:
+			return
+		case <-h.serveDone:
+		}
+
+		select {
+		case <-h.shutdownStarted:
+			return // server stopped because of the shutdown
+		default:
+		}
+
+		err := &goroutine.EarlyExitError{
+			Name: h.name,
+			Err:  h.serveErr,
+		}
+		select {
+		case ch <- err:
+		case <-h.shutdownStarted:
+		}
+	}()
+}
+
+func (h *httpServerHandler) trackConnection(conn net.Conn, state http.ConnState) {
+	h.connectionsMutex.Lock()
+	defer h.connectionsMutex.Unlock()
+	switch state {
+	case http.StateNew:
+		h.connections[conn] = struct{}{}
+	case http.StateClosed, http.StateHijacked:
+		delete(h.connections, conn)
+	case http.StateActive, http.StateIdle:
+	}
+}
@@ -0,0 +1,220 @@
+package httpserver
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/qdm12/goshutdown/goroutine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newLoopbackListener(t *testing.T) net.Listener {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	return listener
+}
+
+func Test_httpServerHandler_Name(t *testing.T) {
+	t.Parallel()
+	const name = "name"
+
+	h := &httpServerHandler{
+		name: name,
+	}
+	n := h.Name()
+
+	assert.Equal(t, name, n)
+}
+
+func Test_httpServerHandler_IsCritical(t *testing.T) {
+	t.Parallel()
+	const critical = true
+
+	h := &httpServerHandler{
+		settings: settings{critical: critical},
+	}
+	c := h.IsCritical()
+
+	assert.Equal(t, critical, c)
+}
+
+func Test_httpServerHandler_Start_error(t *testing.T) {
+	t.Parallel()
+
+	server := &http.Server{Addr: "127.0.0.1:-1"} //nolint:gosec
+	h := New("server", server)
+
+	err := h.Start()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "listening: ")
+}
+
+func Test_httpServerHandler_Shutdown_not_started(t *testing.T) {
+	t.Parallel()
+
+	h := New("server", &http.Server{}) //nolint:gosec
+
+	err := h.Shutdown(context.Background())
+	assert.NoError(t, err)
+}
+
+func Test_httpServerHandler_Shutdown_twice(t *testing.T) {
+	t.Parallel()
+
+	listener := newLoopbackListener(t)
+	h := New("server", &http.Server{}, OptionListener(listener)) //nolint:gosec
+
+	err := h.Start()
+	require.NoError(t, err)
+
+	err = h.Shutdown(context.Background())
+	require.NoError(t, err)
+
+	err = h.Shutdown(context.Background())
+	assert.NoError(t, err)
+}
+
+func Test_httpServerHandler_Shutdown_no_timeout(t *testing.T) {
+	t.Parallel()
+
+	listener := newLoopbackListener(t)
+	requestStarted := make(chan struct{})
+	server := &http.Server{ //nolint:gosec
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			close(requestStarted)
+			time.Sleep(10 * time.Millisecond)
+		}),
+	}
+	h := New("server", server, OptionListener(listener), OptionTimeout(0))
+
+	err := h.Start()
+	require.NoError(t, err)
+
+	requestErr := make(chan error)
+	go func() {
+		resp, err := http.Get("http://" + listener.Addr().String()) //nolint:noctx
+		if err == nil {
+			resp.Body.Close()
+		}
+		requestErr <- err
+	}()
+
+	<-requestStarted
+	err = h.Shutdown(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, h.ForciblyClosed())
+	assert.NoError(t, <-requestErr)
+}
+
+func Test_httpServerHandler_Shutdown_drained(t *testing.T) {
+	t.Parallel()
+
+	listener := newLoopbackListener(t)
+	requestStarted := make(chan struct{})
+	server := &http.Server{ //nolint:gosec
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			close(requestStarted)
+			time.Sleep(10 * time.Millisecond)
+			_, _ = w.Write([]byte("done"))
+		}),
+	}
+	h := New("server", server, OptionListener(listener), OptionTimeout(time.Second))
+
+	err := h.Start()
+	require.NoError(t, err)
+
+	type response struct {
+		body string
+		err  error
+	}
+	responseCh := make(chan response)
+	go func() {
+		response := response{}
+		resp, err := http.Get("http://" + listener.Addr().String()) //nolint:noctx
+		if err != nil {
+			response.err = err
+			responseCh <- response
+			return
+		}
+		defer resp.Body.Close()
+		b, err := io.ReadAll(resp.Body)
+		response.body, response.err = string(b), err
+		responseCh <- response
+	}()
+
+	<-requestStarted
+	err = h.Shutdown(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, h.ForciblyClosed())
+
+	resp := <-responseCh
+	require.NoError(t, resp.err)
+	assert.Equal(t, "done", resp.body)
+}
+
+func Test_httpServerHandler_Shutdown_forced(t *testing.T) {
+	t.Parallel()
+
+	listener := newLoopbackListener(t)
+	requestStarted := make(chan struct{})
+	server := &http.Server{ //nolint:gosec
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			close(requestStarted)
+			<-r.Context().Done()
+		}),
+	}
+	h := New("server", server, OptionListener(listener), OptionTimeout(10*time.Millisecond))
+
+	err := h.Start()
+	require.NoError(t, err)
+
+	requestErr := make(chan error)
+	go func() {
+		resp, err := http.Get("http://" + listener.Addr().String()) //nolint:noctx
+		if err == nil {
+			resp.Body.Close()
+		}
+		requestErr <- err
+	}()
+
+	<-requestStarted
+	err = h.Shutdown(context.Background())
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrTimeout)
+	assert.Equal(t, "http server shutdown timed out: after 10ms: 1 connections forcibly closed", err.Error())
+	assert.Equal(t, 1, h.ForciblyClosed())
+
+	assert.Error(t, <-requestErr)
+}
+
+func Test_httpServerHandler_NotifyEarlyExit(t *testing.T) {
+	t.Parallel()
+
+	listener := newLoopbackListener(t)
+	h := New("server", &http.Server{}, OptionListener(listener)) //nolint:gosec
+
+	err := h.Start()
+	require.NoError(t, err)
+
+	earlyExits := make(chan error)
+	h.NotifyEarlyExit(earlyExits)
+
+	err = listener.Close()
+	require.NoError(t, err)
+
+	err = <-earlyExits
+	var earlyExitErr *goroutine.EarlyExitError
+	require.True(t, errors.As(err, &earlyExitErr))
+	assert.Equal(t, "server", earlyExitErr.Name)
+	assert.Error(t, earlyExitErr.Err)
+
+	err = h.Shutdown(context.Background())
+	assert.Error(t, err)
+}
@@ -0,0 +1,33 @@
+package httpserver
+
+import (
+	"net"
+	"time"
+)
+
+type Option func(s *settings)
+
+// OptionTimeout sets a timeout for draining the in-flight requests,
+// after which the remaining connections are forcibly closed.
+// Note the timeout defaults to one second, and no timeout
+// is applied if it is set to 0.
+func OptionTimeout(timeout time.Duration) Option {
+	return func(s *settings) {
+		s.timeout = timeout
+	}
+}
+
+// OptionCritical marks the shutdown operation as critical.
+func OptionCritical() Option {
+	return func(s *settings) {
+		s.critical = true
+	}
+}
+
+// OptionListener sets the listener to serve on, instead of
+// listening on the TCP address of the HTTP server.
+func OptionListener(listener net.Listener) Option {
+	return func(s *settings) {
+		s.listener = listener
+	}
+}
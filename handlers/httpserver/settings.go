@@ -0,0 +1,27 @@
+package httpserver
+
+import (
+	"net"
+	"time"
+)
+
+// settings defines configuration settings for the HTTP server handler.
+type settings struct {
+	// timeout is the timeout for draining the in-flight requests,
+	// after which the remaining connections are forcibly closed.
+	// It defaults to 1s if left unset, and no timeout is applied
+	// if it is set to 0.
+	timeout time.Duration
+	// critical can be set to true to indicate the shutdown process should exit if
+	// the HTTP server cannot be shut down gracefully.
+	critical bool
+	// listener is the listener to serve on. If left unset, a TCP
+	// listener is created on the server address when starting.
+	listener net.Listener
+}
+
+func newSettings() settings {
+	return settings{
+		timeout: time.Second,
+	}
+}
@@ -0,0 +1,40 @@
+//go:build !windows
+// +build !windows
+
+package process
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"syscall"
+)
+
+// setProcessGroup sets the command to start in its own process group.
+func setProcessGroup(cmd *exec.Cmd) {
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = new(syscall.SysProcAttr)
+	}
+	cmd.SysProcAttr.Setpgid = true
+}
+
+// killProcessGroup kills the entire process group of the command.
+// It returns os.ErrProcessDone if the process group no longer exists.
+func killProcessGroup(cmd *exec.Cmd) (err error) {
+	err = syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	if errors.Is(err, syscall.ESRCH) {
+		return os.ErrProcessDone
+	}
+	return err
+}
+
+// exitedFromSignal returns true if the wait error given is
+// the one of a process terminated by the signal given.
+func exitedFromSignal(waitErr error, signal os.Signal) bool {
+	var exitErr *exec.ExitError
+	if !errors.As(waitErr, &exitErr) {
+		return false
+	}
+	status, ok := exitErr.Sys().(syscall.WaitStatus)
+	return ok && status.Signaled() && status.Signal() == signal
+}
@@ -0,0 +1,24 @@
+//go:build windows
+// +build windows
+
+package process
+
+import (
+	"os"
+	"os/exec"
+)
+
+// setProcessGroup is a no-op on Windows.
+func setProcessGroup(cmd *exec.Cmd) {}
+
+// killProcessGroup kills the process of the command only on Windows.
+// It returns os.ErrProcessDone if the process already exited.
+func killProcessGroup(cmd *exec.Cmd) (err error) {
+	return cmd.Process.Kill()
+}
+
+// exitedFromSignal always returns false on Windows,
+// where processes are not terminated by signals.
+func exitedFromSignal(waitErr error, signal os.Signal) bool {
+	return false
+}
@@ -0,0 +1,213 @@
+// Package process defines a shutdown handler for a child process.
+package process
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"sync"
+	"time"
+
+	"github.com/qdm12/goshutdown/goroutine"
+)
+
+//go:generate mockgen -destination=mock_$GOPACKAGE/$GOFILE . Handler
+
+// Handler handles the shutdown of a child process.
+type Handler interface {
+	// Name returns the name set for this process handler.
+	Name() string
+	// IsCritical returns true if the process handler is critical
+	// and must be terminated before continuing other external shutdown procedures.
+	IsCritical() bool
+	// Start starts the process in its own process group.
+	Start() (err error)
+	// Shutdown sends the signal to the process and waits for it to exit
+	// for the grace period. If it does not exit on time, its entire process
+	// group is killed with SIGKILL and the process is given the timeout to
+	// exit. It returns an error containing the exit status of the process
+	// if the process did not exit successfully, where being terminated by
+	// the signal sent is a successful exit. Calling Shutdown more than
+	// once returns the result of the first call.
+	Shutdown(ctx context.Context) (err error)
+	// NotifyEarlyExit registers the channel given to receive a
+	// *goroutine.EarlyExitError if the process exits before
+	// Shutdown is called. It must be called after Start.
+	NotifyEarlyExit(ch chan<- error)
+}
+
+type processHandler struct {
+	name     string
+	settings settings
+	cmd      *exec.Cmd
+	started  bool
+
+	// shutdownStarted is closed when Shutdown is called.
+	shutdownStarted chan struct{}
+	// shutdownOnce ensures the shutdown is only done once,
+	// with shutdownErr its result returned by each Shutdown call.
+	shutdownOnce sync.Once
+	shutdownErr  error
+	// waitDone is closed once the process exited,
+	// after waitErr is set.
+	waitDone chan struct{}
+	waitErr  error
+}
+
+// New creates a new process handler for the command given.
+// The command should not be started, and is started by Start.
+func New(name string, cmd *exec.Cmd, options ...Option) Handler {
+	settings := newSettings()
+	for _, option := range options {
+		option(&settings)
+	}
+
+	return &processHandler{
+		name:            name,
+		settings:        settings,
+		cmd:             cmd,
+		shutdownStarted: make(chan struct{}),
+		waitDone:        make(chan struct{}),
+	}
+}
+
+func (h *processHandler) Name() string {
+	return h.name
+}
+
+func (h *processHandler) IsCritical() bool {
+	return h.settings.critical
+}
+
+func (h *processHandler) Start() (err error) {
+	setProcessGroup(h.cmd)
+	err = h.cmd.Start()
+	if err != nil {
+		return fmt.Errorf("starting process: %w", err)
+	}
+	h.started = true
+
+	go func() {
+		defer close(h.waitDone)
+		h.waitErr = h.cmd.Wait()
+	}()
+
+	return nil
+}
+
+var (
+	// ErrKilled is the error when the process got killed after the grace period.
+	ErrKilled = errors.New("process killed")
+	// ErrTimeout is the error when the process does not exit after being killed.
+	ErrTimeout = errors.New("process shutdown timed out")
+)
+
+func (h *processHandler) Shutdown(ctx context.Context) (err error) {
+	h.shutdownOnce.Do(func() {
+		h.shutdownErr = h.shutdown(ctx)
+	})
+	return h.shutdownErr
+}
+
+func (h *processHandler) shutdown(ctx context.Context) (err error) {
+	close(h.shutdownStarted)
+
+	if !h.started {
+		return nil
+	}
+
+	select {
+	case <-h.waitDone:
+		return h.exitError()
+	default:
+	}
+
+	err = h.cmd.Process.Signal(h.settings.signal)
+	if err != nil && !errors.Is(err, os.ErrProcessDone) {
+		return fmt.Errorf("signaling process: %w", err)
+	}
+
+	graceTimer := time.NewTimer(h.settings.gracePeriod)
+	defer graceTimer.Stop()
+	select {
+	case <-h.waitDone:
+		return h.signaledExitError()
+	case <-ctx.Done():
+	case <-graceTimer.C:
+	}
+
+	err = killProcessGroup(h.cmd)
+	if errors.Is(err, os.ErrProcessDone) {
+		// the process exited right as the grace period ended
+		select {
+		case <-h.waitDone:
+			return h.signaledExitError()
+		case <-ctx.Done():
+			return ctx.Err() //nolint:wrapcheck
+		}
+	} else if err != nil {
+		return fmt.Errorf("killing process group: %w", err)
+	}
+
+	timer := time.NewTimer(h.settings.timeout)
+	defer timer.Stop()
+	select {
+	case <-h.waitDone:
+		if ctx.Err() != nil {
+			return ctx.Err() //nolint:wrapcheck
+		}
+		return fmt.Errorf("%w: after grace period of %s: %s",
+			ErrKilled, h.settings.gracePeriod, h.waitErr)
+	case <-ctx.Done():
+		return ctx.Err() //nolint:wrapcheck
+	case <-timer.C:
+		return fmt.Errorf("%w: after %s", ErrTimeout, h.settings.timeout)
+	}
+}
+
+// exitError returns an error containing the exit status of the
+// process if it did not exit successfully, and nil otherwise.
+// It must be called once the process exited.
+func (h *processHandler) exitError() (err error) {
+	if h.waitErr == nil {
+		return nil
+	}
+	return fmt.Errorf("process exited: %w", h.waitErr)
+}
+
+// signaledExitError returns nil if the process was terminated by
+// the signal sent to it, and the exitError result otherwise.
+// It must be called once the process exited after being signaled.
+func (h *processHandler) signaledExitError() (err error) {
+	if exitedFromSignal(h.waitErr, h.settings.signal) {
+		return nil
+	}
+	return h.exitError()
+}
+
+func (h *processHandler) NotifyEarlyExit(ch chan<- error) {
+	go func() {
+		select {
+		case <-h.shutdownStarted:
+			return
+		case <-h.waitDone:
+		}
+
+		select {
+		case <-h.shutdownStarted:
+			return // process exited because of the shutdown
+		default:
+		}
+
+		err := &goroutine.EarlyExitError{
+			Name: h.name,
+			Err:  h.exitError(),
+		}
+		select {
+		case ch <- err:
+		case <-h.shutdownStarted:
+		}
+	}()
+}
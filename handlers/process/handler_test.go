@@ -0,0 +1,183 @@
+//go:build linux
+// +build linux
+
+package process
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/qdm12/goshutdown/goroutine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_processHandler_Name(t *testing.T) {
+	t.Parallel()
+	const name = "name"
+
+	h := &processHandler{
+		name: name,
+	}
+	n := h.Name()
+
+	assert.Equal(t, name, n)
+}
+
+func Test_processHandler_IsCritical(t *testing.T) {
+	t.Parallel()
+	const critical = true
+
+	h := &processHandler{
+		settings: settings{critical: critical},
+	}
+	c := h.IsCritical()
+
+	assert.Equal(t, critical, c)
+}
+
+func Test_processHandler_Start_error(t *testing.T) {
+	t.Parallel()
+
+	h := New("process", exec.Command("/non/existent/binary"))
+
+	err := h.Start()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "starting process: ")
+}
+
+// startScript starts a process handler for the shell script given,
+// and waits for the script to print its first line, for example once
+// its trap is set.
+func startScript(t *testing.T, script string, options ...Option) Handler {
+	t.Helper()
+
+	reader, writer, err := os.Pipe()
+	require.NoError(t, err)
+	defer reader.Close()
+
+	cmd := exec.Command("sh", "-c", script)
+	cmd.Stdout = writer
+	h := New("process", cmd, options...)
+	err = h.Start()
+	writer.Close()
+	require.NoError(t, err)
+
+	_, err = bufio.NewReader(reader).ReadString('\n')
+	require.NoError(t, err)
+	return h
+}
+
+func Test_processHandler_Shutdown(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		script  string
+		options []Option
+		errIs   error
+		errMsg  string
+	}{
+		"not started": {},
+		"graceful exit": {
+			script: `trap "exit 0" TERM; echo ready; while true; do sleep 0.01; done`,
+		},
+		"graceful exit with custom signal": {
+			script:  `trap "exit 0" INT; echo ready; while true; do sleep 0.01; done`,
+			options: []Option{OptionSignal(syscall.SIGINT)},
+		},
+		"terminated by the signal": {
+			script: `echo ready; exec sleep 10`,
+		},
+		"terminated by the custom signal": {
+			script:  `echo ready; exec sleep 10`,
+			options: []Option{OptionSignal(syscall.SIGINT)},
+		},
+		"exit status": {
+			script: `trap "exit 3" TERM; echo ready; while true; do sleep 0.01; done`,
+			errMsg: "process exited: exit status 3",
+		},
+		"killed after grace period": {
+			script:  `trap "" TERM; echo ready; sleep 10 & wait`,
+			options: []Option{OptionGracePeriod(50 * time.Millisecond)},
+			errIs:   ErrKilled,
+			errMsg:  "process killed: after grace period of 50ms: signal: killed",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var h Handler
+			if testCase.script == "" {
+				h = New("process", exec.Command("true"), testCase.options...)
+			} else {
+				h = startScript(t, testCase.script, testCase.options...)
+			}
+
+			err := h.Shutdown(context.Background())
+
+			switch {
+			case testCase.errMsg != "":
+				require.Error(t, err)
+				assert.Equal(t, testCase.errMsg, err.Error())
+				if testCase.errIs != nil {
+					assert.ErrorIs(t, err, testCase.errIs)
+				}
+			default:
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_processHandler_Shutdown_twice(t *testing.T) {
+	t.Parallel()
+
+	h := startScript(t, `trap "exit 3" TERM; echo ready; while true; do sleep 0.01; done`)
+
+	err := h.Shutdown(context.Background())
+	require.EqualError(t, err, "process exited: exit status 3")
+
+	err = h.Shutdown(context.Background())
+	assert.EqualError(t, err, "process exited: exit status 3")
+}
+
+func Test_killProcessGroup_exited(t *testing.T) {
+	t.Parallel()
+
+	cmd := exec.Command("true")
+	setProcessGroup(cmd)
+	err := cmd.Run()
+	require.NoError(t, err)
+
+	err = killProcessGroup(cmd)
+	assert.ErrorIs(t, err, os.ErrProcessDone)
+}
+
+func Test_processHandler_NotifyEarlyExit(t *testing.T) {
+	t.Parallel()
+
+	h := New("process", exec.Command("sh", "-c", "exit 1"))
+
+	err := h.Start()
+	require.NoError(t, err)
+
+	earlyExits := make(chan error)
+	h.NotifyEarlyExit(earlyExits)
+
+	err = <-earlyExits
+	var earlyExitErr *goroutine.EarlyExitError
+	require.True(t, errors.As(err, &earlyExitErr))
+	assert.Equal(t, "process terminated before shutdown: process exited: exit status 1", err.Error())
+
+	err = h.Shutdown(context.Background())
+	assert.EqualError(t, err, "process exited: exit status 1")
+}
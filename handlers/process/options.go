@@ -0,0 +1,41 @@
+package process
+
+import (
+	"os"
+	"time"
+)
+
+type Option func(s *settings)
+
+// OptionSignal sets the signal sent to the process to terminate it gracefully.
+// Note the signal defaults to SIGTERM.
+func OptionSignal(signal os.Signal) Option {
+	return func(s *settings) {
+		s.signal = signal
+	}
+}
+
+// OptionGracePeriod sets the time to wait for the process to exit after
+// sending it the signal, before killing its entire process group.
+// Note the grace period defaults to one second.
+func OptionGracePeriod(gracePeriod time.Duration) Option {
+	return func(s *settings) {
+		s.gracePeriod = gracePeriod
+	}
+}
+
+// OptionTimeout sets a timeout for the process to exit after its
+// process group got killed.
+// Note the timeout defaults to one second.
+func OptionTimeout(timeout time.Duration) Option {
+	return func(s *settings) {
+		s.timeout = timeout
+	}
+}
+
+// OptionCritical marks the shutdown operation as critical.
+func OptionCritical() Option {
+	return func(s *settings) {
+		s.critical = true
+	}
+}
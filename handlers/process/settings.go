@@ -0,0 +1,32 @@
+package process
+
+import (
+	"os"
+	"syscall"
+	"time"
+)
+
+// settings defines configuration settings for the process handler.
+type settings struct {
+	// signal is the signal sent to the process to terminate it gracefully.
+	// It defaults to SIGTERM if left unset.
+	signal os.Signal
+	// gracePeriod is the time to wait for the process to exit after
+	// sending it the signal, before killing its entire process group.
+	// It defaults to 1s if left unset.
+	gracePeriod time.Duration
+	// timeout is the timeout for the process to exit after its process
+	// group got killed. It defaults to 1s if left unset.
+	timeout time.Duration
+	// critical can be set to true to indicate the shutdown process should exit if
+	// this process cannot be terminated.
+	critical bool
+}
+
+func newSettings() settings {
+	return settings{
+		signal:      syscall.SIGTERM,
+		gracePeriod: time.Second,
+		timeout:     time.Second,
+	}
+}
@@ -0,0 +1,23 @@
+package process
+
+import (
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_newSettings(t *testing.T) {
+	t.Parallel()
+
+	s := newSettings()
+
+	expected := settings{
+		signal:      syscall.SIGTERM,
+		gracePeriod: time.Second,
+		timeout:     time.Second,
+	}
+
+	assert.Equal(t, expected, s)
+}
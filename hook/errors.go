@@ -0,0 +1,22 @@
+package hook
+
+import "strconv"
+
+// Error is the error of a hook which failed.
+type Error struct {
+	// Name is the name of the hook.
+	Name string
+	// Phase is the phase the hook ran in.
+	Phase Phase
+	// Err is the error returned by the hook.
+	Err error
+}
+
+func (e *Error) Error() string {
+	return string(e.Phase) + " hook " + strconv.Quote(e.Name) + " failed: " + e.Err.Error()
+}
+
+// Unwrap returns the error returned by the hook.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
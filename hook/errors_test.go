@@ -0,0 +1,22 @@
+package hook
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Error(t *testing.T) {
+	t.Parallel()
+
+	errTest := errors.New("test error")
+	err := &Error{
+		Name:  "flush metrics",
+		Phase: PhaseBefore,
+		Err:   errTest,
+	}
+
+	assert.EqualError(t, err, `before hook "flush metrics" failed: test error`)
+	assert.ErrorIs(t, err, errTest)
+}
@@ -0,0 +1,53 @@
+// Package hook defines hooks to run before or after the shutdown
+// of handlers, such as flushing metrics or writing a final log line.
+package hook
+
+import (
+	"context"
+	"time"
+
+	"github.com/qdm12/goshutdown/report"
+)
+
+// Hook is a named function to run before or after the shutdown
+// of an handler. Hooks are given the shutdown context of the
+// handler, and therefore share its timeout budget.
+type Hook struct {
+	// Name is the name of the hook, used in reports and callbacks.
+	Name string
+	// Fn is the function to run.
+	Fn func(ctx context.Context) error
+}
+
+// Phase is the phase a hook runs in.
+type Phase string
+
+const (
+	// PhaseBefore is the phase of hooks running before the shutdown.
+	PhaseBefore Phase = "before"
+	// PhaseAfter is the phase of hooks running after the shutdown.
+	PhaseAfter Phase = "after"
+)
+
+// Run runs the hook given and returns its report of kind report.KindHook,
+// and an *Error wrapping the hook error if it failed.
+func Run(ctx context.Context, phase Phase, hook Hook) (
+	rep *report.Report, err error) {
+	start := time.Now()
+	hookErr := hook.Fn(ctx)
+	if hookErr != nil {
+		err = &Error{
+			Name:  hook.Name,
+			Phase: phase,
+			Err:   hookErr,
+		}
+	}
+
+	rep = &report.Report{
+		Name:     hook.Name,
+		Kind:     report.KindHook,
+		Duration: time.Since(start),
+	}
+	rep.SetError(err)
+	return rep, err
+}
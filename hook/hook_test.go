@@ -0,0 +1,55 @@
+package hook
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/qdm12/goshutdown/report"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Run(t *testing.T) {
+	t.Parallel()
+
+	errTest := errors.New("test error")
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		hook := Hook{
+			Name: "flush",
+			Fn: func(hookCtx context.Context) error {
+				assert.Equal(t, ctx, hookCtx)
+				return nil
+			},
+		}
+
+		rep, err := Run(ctx, PhaseBefore, hook)
+
+		require.NoError(t, err)
+		assert.Equal(t, "flush", rep.Name)
+		assert.Equal(t, report.KindHook, rep.Kind)
+		assert.Equal(t, report.StatusSuccess, rep.Status)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		t.Parallel()
+
+		hook := Hook{
+			Name: "flush",
+			Fn: func(context.Context) error {
+				return errTest
+			},
+		}
+
+		rep, err := Run(context.Background(), PhaseAfter, hook)
+
+		expectedErr := &Error{Name: "flush", Phase: PhaseAfter, Err: errTest}
+		assert.Equal(t, expectedErr, err)
+		assert.Equal(t, report.StatusFailure, rep.Status)
+		assert.Equal(t, `after hook "flush" failed: test error`, rep.Error)
+	})
+}
@@ -0,0 +1,19 @@
+package hook
+
+import "context"
+
+type Option func(s *settings)
+
+// OptionBefore adds a hook to run before the shutdown of the wrapped handler.
+func OptionBefore(name string, fn func(ctx context.Context) error) Option {
+	return func(s *settings) {
+		s.before = append(s.before, Hook{Name: name, Fn: fn})
+	}
+}
+
+// OptionAfter adds a hook to run after the shutdown of the wrapped handler.
+func OptionAfter(name string, fn func(ctx context.Context) error) Option {
+	return func(s *settings) {
+		s.after = append(s.after, Hook{Name: name, Fn: fn})
+	}
+}
@@ -0,0 +1,15 @@
+package hook
+
+// settings defines configuration settings for a wrapped handler.
+type settings struct {
+	// before are the hooks to run, in order, before the shutdown
+	// of the wrapped handler.
+	before []Hook
+	// after are the hooks to run, in order, after the shutdown
+	// of the wrapped handler.
+	after []Hook
+}
+
+func newSettings() settings {
+	return settings{}
+}
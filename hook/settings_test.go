@@ -0,0 +1,15 @@
+package hook
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_newSettings(t *testing.T) {
+	t.Parallel()
+
+	s := newSettings()
+
+	assert.Equal(t, settings{}, s)
+}
@@ -0,0 +1,106 @@
+package hook
+
+import (
+	"context"
+	"time"
+
+	"github.com/qdm12/goshutdown/handler"
+	"github.com/qdm12/goshutdown/report"
+)
+
+//go:generate mockgen -destination=mock_$GOPACKAGE/$GOFILE . Handler
+
+// Handler handles the shutdown of an handler wrapped with hooks.
+type Handler interface {
+	// Name returns the name of the wrapped handler.
+	Name() string
+	// IsCritical returns true if the wrapped handler is critical.
+	IsCritical() bool
+	// Shutdown runs the before hooks, shuts down the wrapped handler
+	// and runs the after hooks. All the hooks run sequentially with the
+	// shutdown context given, regardless of errors of the wrapped handler
+	// or of other hooks. It returns the shutdown error of the wrapped
+	// handler if any, and otherwise the *Error of the first hook which failed.
+	Shutdown(ctx context.Context) (err error)
+	// ShutdownReport shuts down as Shutdown does, and returns the shutdown
+	// report of the wrapped handler, with the hooks reports as children,
+	// before and after the children of the wrapped handler. The duration
+	// of the report includes the duration of the hooks.
+	ShutdownReport(ctx context.Context) (report *report.Report, err error)
+	// NotifyEarlyExit calls NotifyEarlyExit on the wrapped handler if
+	// it implements the handler.EarlyExitNotifier interface.
+	NotifyEarlyExit(ch chan<- error)
+}
+
+// Wrap wraps the handler given with the hooks set in the options given.
+func Wrap(h handler.Handler, options ...Option) Handler {
+	settings := newSettings()
+	for _, option := range options {
+		option(&settings)
+	}
+
+	return &wrapHandler{
+		handler:  h,
+		settings: settings,
+	}
+}
+
+type wrapHandler struct {
+	handler  handler.Handler
+	settings settings
+}
+
+func (h *wrapHandler) Name() string {
+	return h.handler.Name()
+}
+
+func (h *wrapHandler) IsCritical() bool {
+	return h.handler.IsCritical()
+}
+
+func (h *wrapHandler) Shutdown(ctx context.Context) (err error) {
+	_, err = h.ShutdownReport(ctx)
+	return err
+}
+
+func (h *wrapHandler) ShutdownReport(ctx context.Context) (
+	rep *report.Report, err error) {
+	start := time.Now()
+	var hooksErr error
+	runHooks := func(phase Phase, hooks []Hook) (reports []*report.Report) {
+		reports = make([]*report.Report, len(hooks))
+		for i, hook := range hooks {
+			var hookErr error
+			reports[i], hookErr = Run(ctx, phase, hook)
+			if hooksErr == nil {
+				hooksErr = hookErr
+			}
+		}
+		return reports
+	}
+
+	beforeReports := runHooks(PhaseBefore, h.settings.before)
+	rep, err = report.Shutdown(ctx, h.handler, h.handler.Name(), h.handler.IsCritical())
+	afterReports := runHooks(PhaseAfter, h.settings.after)
+
+	children := make([]*report.Report, 0,
+		len(beforeReports)+len(rep.Children)+len(afterReports))
+	children = append(children, beforeReports...)
+	children = append(children, rep.Children...)
+	children = append(children, afterReports...)
+	rep.Children = children
+	rep.Duration = time.Since(start)
+
+	if err == nil {
+		err = hooksErr
+		rep.SetError(err)
+	}
+	return rep, err
+}
+
+func (h *wrapHandler) NotifyEarlyExit(ch chan<- error) {
+	notifier, ok := h.handler.(handler.EarlyExitNotifier)
+	if ok {
+		notifier.NotifyEarlyExit(ch)
+	}
+}
@@ -0,0 +1,94 @@
+package hook
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/qdm12/goshutdown/handler/mock_handler"
+	"github.com/qdm12/goshutdown/report"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Wrap(t *testing.T) {
+	t.Parallel()
+
+	errTest := errors.New("test error")
+	errHook := errors.New("hook error")
+
+	testCases := map[string]struct {
+		handlerErr error
+		hookErr    error
+		err        error
+		errMessage string
+		status     report.Status
+	}{
+		"success": {
+			status: report.StatusSuccess,
+		},
+		"handler error": {
+			handlerErr: errTest,
+			hookErr:    errHook,
+			err:        errTest,
+			errMessage: "test error",
+			status:     report.StatusFailure,
+		},
+		"hook error": {
+			hookErr:    errHook,
+			err:        errHook,
+			errMessage: `before hook "before 1" failed: hook error`,
+			status:     report.StatusFailure,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			var calls []string
+			record := func(name string, err error) func(ctx context.Context) error {
+				return func(ctx context.Context) error {
+					calls = append(calls, name)
+					return err
+				}
+			}
+
+			wrapped := mock_handler.NewMockHandler(ctrl)
+			wrapped.EXPECT().Name().Return("name").AnyTimes()
+			wrapped.EXPECT().IsCritical().Return(true).AnyTimes()
+			wrapped.EXPECT().Shutdown(gomock.Any()).DoAndReturn(
+				record("shutdown", testCase.handlerErr))
+
+			h := Wrap(wrapped,
+				OptionBefore("before 1", record("before 1", testCase.hookErr)),
+				OptionBefore("before 2", record("before 2", nil)),
+				OptionAfter("after", record("after", nil)),
+			)
+
+			assert.Equal(t, "name", h.Name())
+			assert.True(t, h.IsCritical())
+
+			rep, err := h.ShutdownReport(context.Background())
+
+			assert.Equal(t, []string{"before 1", "before 2", "shutdown", "after"}, calls)
+			if testCase.err != nil {
+				assert.ErrorIs(t, err, testCase.err)
+				assert.EqualError(t, err, testCase.errMessage)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, "name", rep.Name)
+			assert.True(t, rep.Critical)
+			assert.Equal(t, testCase.status, rep.Status)
+			require.Len(t, rep.Children, 3)
+			assert.Equal(t, "before 1", rep.Children[0].Name)
+			assert.Equal(t, "before 2", rep.Children[1].Name)
+			assert.Equal(t, "after", rep.Children[2].Name)
+		})
+	}
+}
@@ -0,0 +1,31 @@
+// Package ready defines helpers to start handlers and wait for
+// them to be ready, shared by the order, group and graph handlers.
+package ready
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/qdm12/goshutdown/handler"
+)
+
+// StartAndWait starts the handler given and waits for it to be ready,
+// within the timeout given if it is not 0. It returns an error wrapping
+// errTimeout if the timeout elapses before the handler is ready.
+func StartAndWait(ctx context.Context, starter handler.ReadyStarter,
+	timeout time.Duration, errTimeout error) (err error) {
+	if timeout == 0 {
+		return starter.StartAndWaitReady(ctx)
+	}
+
+	readyCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	err = starter.StartAndWaitReady(readyCtx)
+	if errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil {
+		return fmt.Errorf("%w: after %s", errTimeout, timeout)
+	}
+	return err
+}
@@ -0,0 +1,57 @@
+// Package results defines helpers over the shutdown results of handlers,
+// shared by the shutdown errors of the order, group and graph handlers.
+package results
+
+import (
+	"errors"
+
+	"github.com/qdm12/goshutdown/handler"
+)
+
+// Failed returns the results with an error.
+func Failed(results []handler.Result) (failed []handler.Result) {
+	for _, result := range results {
+		if result.Err != nil {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}
+
+// Messages returns a "name: error" message for each of the results given.
+func Messages(results []handler.Result) (messages []string) {
+	messages = make([]string, len(results))
+	for i, result := range results {
+		messages[i] = result.Name + ": " + result.Err.Error()
+	}
+	return messages
+}
+
+// Is returns true if the target is errCritical for a non nil critical
+// failure, errTimeout otherwise, or if the target matches one of the
+// errors of the failed results.
+func Is(results []handler.Result, criticalFailure *handler.Result,
+	target, errCritical, errTimeout error) bool {
+	if criticalFailure != nil && target == errCritical ||
+		criticalFailure == nil && target == errTimeout {
+		return true
+	}
+
+	for _, result := range Failed(results) {
+		if errors.Is(result.Err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As finds the first error of the failed results matching the target,
+// and if so sets the target to that error value and returns true.
+func As(results []handler.Result, target interface{}) bool {
+	for _, result := range Failed(results) {
+		if errors.As(result.Err, target) {
+			return true
+		}
+	}
+	return false
+}
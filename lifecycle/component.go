@@ -0,0 +1,115 @@
+package lifecycle
+
+import (
+	"context"
+
+	"github.com/qdm12/goshutdown/goroutine"
+	"github.com/qdm12/goshutdown/handler"
+)
+
+//go:generate mockgen -destination=mock_$GOPACKAGE/$GOFILE . Component,Lifecycle
+
+// Component is a part of the program which can be started and stopped.
+type Component interface {
+	// Name returns the name set for this component.
+	Name() string
+	// IsCritical returns true if the component is critical and must be
+	// stopped before continuing other external shutdown procedures.
+	IsCritical() bool
+	// Start starts the component and returns once the component is started,
+	// leaving any long running work to run in the background. It returns
+	// an error if the component failed to start, in which case Stop is
+	// not called.
+	Start(ctx context.Context) (err error)
+	// Stop stops the component, and is only called if Start succeeded.
+	Stop(ctx context.Context) (err error)
+}
+
+// NewFunc creates a component calling the start function given on start,
+// and the stop function given on stop.
+func NewFunc(name string, start, stop func(ctx context.Context) error,
+	options ...Option) Component {
+	settings := newSettings()
+	for _, option := range options {
+		option(&settings)
+	}
+
+	return &funcComponent{
+		name:     name,
+		settings: settings,
+		start:    start,
+		stop:     stop,
+	}
+}
+
+type funcComponent struct {
+	name     string
+	settings settings
+	start    func(ctx context.Context) error
+	stop     func(ctx context.Context) error
+}
+
+func (c *funcComponent) Name() string {
+	return c.name
+}
+
+func (c *funcComponent) IsCritical() bool {
+	return c.settings.critical
+}
+
+func (c *funcComponent) Start(ctx context.Context) (err error) {
+	return c.start(ctx)
+}
+
+func (c *funcComponent) Stop(ctx context.Context) (err error) {
+	return c.stop(ctx)
+}
+
+// Go creates a component launching the function given in a goroutine
+// with goroutine.Go on start, and shutting down the goroutine handler on stop.
+// The component criticality is the one set by the goroutine options given.
+func Go(name string, fn func(ctx context.Context) error,
+	options ...goroutine.Option) Component {
+	return &goComponent{
+		name:     name,
+		critical: goroutine.IsCritical(options...),
+		fn:       fn,
+		options:  options,
+	}
+}
+
+type goComponent struct {
+	name     string
+	critical bool
+	fn       func(ctx context.Context) error
+	options  []goroutine.Option
+	// handler is the goroutine handler, set on start.
+	handler goroutine.Handler
+}
+
+func (c *goComponent) Name() string {
+	return c.name
+}
+
+func (c *goComponent) IsCritical() bool {
+	return c.critical
+}
+
+func (c *goComponent) Start(context.Context) (err error) {
+	c.handler = goroutine.Go(c.name, c.fn, c.options...)
+	return nil
+}
+
+func (c *goComponent) Stop(ctx context.Context) (err error) {
+	return c.handler.Shutdown(ctx)
+}
+
+// NotifyEarlyExit registers the channel given to receive a
+// *goroutine.EarlyExitError if the goroutine terminates before
+// the component is stopped. It must be called after Start.
+func (c *goComponent) NotifyEarlyExit(ch chan<- error) {
+	notifier, ok := c.handler.(handler.EarlyExitNotifier)
+	if ok {
+		notifier.NotifyEarlyExit(ch)
+	}
+}
@@ -0,0 +1,59 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/qdm12/goshutdown/goroutine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_NewFunc(t *testing.T) {
+	t.Parallel()
+
+	errTest := errors.New("test error")
+
+	var calls []string
+	c := NewFunc("name", func(ctx context.Context) error {
+		calls = append(calls, "start")
+		return nil
+	}, func(ctx context.Context) error {
+		calls = append(calls, "stop")
+		return errTest
+	}, OptionCritical())
+
+	assert.Equal(t, "name", c.Name())
+	assert.True(t, c.IsCritical())
+
+	err := c.Start(context.Background())
+	require.NoError(t, err)
+
+	err = c.Stop(context.Background())
+	assert.Equal(t, errTest, err)
+
+	assert.Equal(t, []string{"start", "stop"}, calls)
+}
+
+func Test_Go(t *testing.T) {
+	t.Parallel()
+
+	errTest := errors.New("test error")
+
+	c := Go("name", func(ctx context.Context) error {
+		<-ctx.Done()
+		return errTest
+	}, goroutine.OptionCritical())
+
+	assert.Equal(t, "name", c.Name())
+	assert.True(t, c.IsCritical())
+
+	err := c.Start(context.Background())
+	require.NoError(t, err)
+
+	err = c.Stop(context.Background())
+	assert.ErrorIs(t, err, errTest)
+	var exitErr *goroutine.ExitError
+	assert.ErrorAs(t, err, &exitErr)
+}
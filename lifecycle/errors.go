@@ -0,0 +1,26 @@
+package lifecycle
+
+// StartError is the error returned by Start when a component failed
+// to start. The components already started are then stopped.
+type StartError struct {
+	// Name is the name of the component which failed to start.
+	Name string
+	// Err is the error the component failed to start with.
+	Err error
+	// RollbackErr is the error stopping the components already started,
+	// and is nil if they all stopped successfully.
+	RollbackErr error
+}
+
+func (e *StartError) Error() string {
+	message := "starting " + e.Name + ": " + e.Err.Error()
+	if e.RollbackErr != nil {
+		message += ": rolling back: " + e.RollbackErr.Error()
+	}
+	return message
+}
+
+// Unwrap returns the error the component failed to start with.
+func (e *StartError) Unwrap() error {
+	return e.Err
+}
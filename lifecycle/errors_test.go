@@ -0,0 +1,45 @@
+package lifecycle
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_StartError(t *testing.T) {
+	t.Parallel()
+
+	errTest := errors.New("test error")
+
+	testCases := map[string]struct {
+		err     *StartError
+		message string
+	}{
+		"rollback success": {
+			err: &StartError{
+				Name: "database",
+				Err:  errTest,
+			},
+			message: "starting database: test error",
+		},
+		"rollback failure": {
+			err: &StartError{
+				Name:        "database",
+				Err:         errTest,
+				RollbackErr: errors.New("rollback error"),
+			},
+			message: "starting database: test error: rolling back: rollback error",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.EqualError(t, testCase.err, testCase.message)
+			assert.ErrorIs(t, testCase.err, errTest)
+		})
+	}
+}
@@ -0,0 +1,251 @@
+// Package lifecycle defines components which can be started and stopped,
+// and lifecycles starting them in order or in parallel and stopping
+// them using the shutdown handlers of the order and group packages.
+package lifecycle
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/qdm12/goshutdown/group"
+	"github.com/qdm12/goshutdown/handler"
+	"github.com/qdm12/goshutdown/order"
+	"github.com/qdm12/goshutdown/report"
+)
+
+// Lifecycle starts and stops components. It is itself a Component as well as
+// a handler.Handler, so it can be nested in another lifecycle, in an order or
+// group handler, or given to runner.Run once started.
+// The lifecycles created by NewOrder and NewGroup also implement
+// handler.EarlyExitNotifier, handler.Parent and handler.Timeouter.
+type Lifecycle interface {
+	Component
+	// Shutdown stops the started components, using an order or group
+	// handler depending on how the lifecycle was created.
+	// It returns the error of the order or group handler.
+	Shutdown(ctx context.Context) (err error)
+	// ShutdownReport stops the started components as Shutdown does, and
+	// returns the shutdown report of the lifecycle and of its components.
+	ShutdownReport(ctx context.Context) (report *report.Report, err error)
+	// Add adds one or more components to the lifecycle. Components
+	// must be added before the lifecycle is started.
+	Add(components ...Component)
+}
+
+// NewOrder creates a lifecycle starting its components sequentially in the
+// order they were added, and stopping them in the reverse order using an
+// order handler created with the name and options given. If a component
+// fails to start, the components already started are stopped in the reverse
+// order and Start returns a *StartError.
+func NewOrder(name string, options ...order.Option) Lifecycle {
+	stopper := order.NewReverse(name, options...)
+	return newLifecycle(name, false, stopper, stopper.Append)
+}
+
+// NewGroup creates a lifecycle starting its components in parallel, and
+// stopping them in parallel using a group handler created with the name and
+// options given. If one or more components fail to start, the context given
+// to the other components Start method is canceled, the components started
+// are stopped and Start returns a *StartError for the first component
+// which failed to start.
+func NewGroup(name string, options ...group.Option) Lifecycle {
+	stopper := group.New(name, options...)
+	return newLifecycle(name, true, stopper, stopper.Add)
+}
+
+func newLifecycle(name string, parallel bool, stopper handler.Handler,
+	addStarted func(handlers ...handler.Handler)) *lifecycle {
+	return &lifecycle{
+		name:       name,
+		parallel:   parallel,
+		stopper:    stopper,
+		addStarted: addStarted,
+	}
+}
+
+type lifecycle struct {
+	name       string
+	parallel   bool
+	components []Component
+	// stopper is the order or group handler stopping the started
+	// components, created once with the lifecycle.
+	stopper handler.Handler
+	// addStarted adds the shutdown handlers of started components
+	// to the stopper.
+	addStarted func(handlers ...handler.Handler)
+}
+
+func (l *lifecycle) Name() string {
+	return l.name
+}
+
+func (l *lifecycle) IsCritical() bool {
+	return l.stopper.IsCritical()
+}
+
+// Children returns the shutdown handlers of the started components,
+// in the order they are stopped for an order lifecycle.
+func (l *lifecycle) Children() []handler.Handler {
+	parent, ok := l.stopper.(handler.Parent)
+	if !ok {
+		return nil
+	}
+	return parent.Children()
+}
+
+// Timeout returns the timeout of the order or group handler
+// stopping the components, where 0 indicates no timeout.
+func (l *lifecycle) Timeout() time.Duration {
+	timeouter, ok := l.stopper.(handler.Timeouter)
+	if !ok {
+		return 0
+	}
+	return timeouter.Timeout()
+}
+
+// Kind returns report.KindOrder for a lifecycle created with NewOrder,
+// and report.KindGroup for a lifecycle created with NewGroup.
+func (l *lifecycle) Kind() report.Kind {
+	if l.parallel {
+		return report.KindGroup
+	}
+	return report.KindOrder
+}
+
+// NotifyEarlyExit registers the channel given to receive a
+// *goroutine.EarlyExitError for each goroutine of the started components
+// terminating before its shutdown is initiated, including the components
+// started after this call.
+func (l *lifecycle) NotifyEarlyExit(ch chan<- error) {
+	notifier, ok := l.stopper.(handler.EarlyExitNotifier)
+	if ok {
+		notifier.NotifyEarlyExit(ch)
+	}
+}
+
+func (l *lifecycle) Add(components ...Component) {
+	l.components = append(l.components, components...)
+}
+
+func (l *lifecycle) Start(ctx context.Context) (err error) {
+	if l.parallel {
+		return l.startParallel(ctx)
+	}
+
+	for _, component := range l.components {
+		err = component.Start(ctx)
+		if err != nil {
+			return l.rollback(component, err)
+		}
+		l.addStarted(asHandler(component))
+	}
+	return nil
+}
+
+func (l *lifecycle) startParallel(ctx context.Context) (err error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	started := make([]bool, len(l.components))
+	var failed Component
+	var failedMutex sync.Mutex
+	var wg sync.WaitGroup
+	for i, component := range l.components {
+		wg.Add(1)
+		go func(i int, component Component) {
+			defer wg.Done()
+			startErr := component.Start(ctx)
+			if startErr == nil {
+				started[i] = true
+				return
+			}
+
+			failedMutex.Lock()
+			defer failedMutex.Unlock()
+			if failed == nil {
+				failed, err = component, startErr
+				cancel()
+			}
+		}(i, component)
+	}
+	wg.Wait()
+
+	for i, component := range l.components {
+		if started[i] {
+			l.addStarted(asHandler(component))
+		}
+	}
+
+	if failed != nil {
+		return l.rollback(failed, err)
+	}
+	return nil
+}
+
+// rollback stops the components already started, with a context
+// independent from the start context which may be canceled already.
+func (l *lifecycle) rollback(failed Component, startErr error) (err error) {
+	rollbackErr := l.Shutdown(context.Background())
+	return &StartError{
+		Name:        failed.Name(),
+		Err:         startErr,
+		RollbackErr: rollbackErr,
+	}
+}
+
+func (l *lifecycle) Stop(ctx context.Context) (err error) {
+	return l.Shutdown(ctx)
+}
+
+func (l *lifecycle) Shutdown(ctx context.Context) (err error) {
+	_, err = l.ShutdownReport(ctx)
+	return err
+}
+
+func (l *lifecycle) ShutdownReport(ctx context.Context) (
+	rep *report.Report, err error) {
+	return report.Shutdown(ctx, l.stopper, l.name, l.stopper.IsCritical())
+}
+
+// asHandler returns the component as a shutdown handler. Lifecycles
+// are returned as is so their shutdown reports are nested in the
+// parent report.
+func asHandler(component Component) handler.Handler {
+	l, ok := component.(Lifecycle)
+	if ok {
+		return l
+	}
+	return &componentHandler{component: component}
+}
+
+// componentHandler is a shutdown handler stopping a component.
+// The component is stopped only once, and further shutdowns
+// return the error of the first stop.
+type componentHandler struct {
+	component Component
+	stopOnce  sync.Once
+	stopErr   error
+}
+
+func (h *componentHandler) Name() string {
+	return h.component.Name()
+}
+
+func (h *componentHandler) IsCritical() bool {
+	return h.component.IsCritical()
+}
+
+func (h *componentHandler) Shutdown(ctx context.Context) (err error) {
+	h.stopOnce.Do(func() {
+		h.stopErr = h.component.Stop(ctx)
+	})
+	return h.stopErr
+}
+
+func (h *componentHandler) NotifyEarlyExit(ch chan<- error) {
+	notifier, ok := h.component.(handler.EarlyExitNotifier)
+	if ok {
+		notifier.NotifyEarlyExit(ch)
+	}
+}
@@ -0,0 +1,289 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/qdm12/goshutdown/goroutine"
+	"github.com/qdm12/goshutdown/group"
+	"github.com/qdm12/goshutdown/handler"
+	"github.com/qdm12/goshutdown/order"
+	"github.com/qdm12/goshutdown/report"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// callRecorder records the start and stop calls of components.
+type callRecorder struct {
+	mutex sync.Mutex
+	calls []string
+}
+
+func (r *callRecorder) record(call string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.calls = append(r.calls, call)
+}
+
+func (r *callRecorder) newComponent(name string, startErr error) Component {
+	return NewFunc(name, func(ctx context.Context) error {
+		r.record("start " + name)
+		return startErr
+	}, func(ctx context.Context) error {
+		r.record("stop " + name)
+		return nil
+	})
+}
+
+func Test_NewOrder(t *testing.T) {
+	t.Parallel()
+
+	l := NewOrder("name", order.OptionCritical())
+
+	assert.Equal(t, "name", l.Name())
+	assert.True(t, l.IsCritical())
+}
+
+func Test_NewGroup(t *testing.T) {
+	t.Parallel()
+
+	l := NewGroup("name")
+
+	assert.Equal(t, "name", l.Name())
+	assert.False(t, l.IsCritical())
+}
+
+func Test_lifecycle_order(t *testing.T) {
+	t.Parallel()
+
+	t.Run("start and stop", func(t *testing.T) {
+		t.Parallel()
+
+		recorder := new(callRecorder)
+		l := NewOrder("order")
+		l.Add(
+			recorder.newComponent("database", nil),
+			recorder.newComponent("cache", nil),
+			recorder.newComponent("server", nil),
+		)
+
+		err := l.Start(context.Background())
+		require.NoError(t, err)
+
+		err = l.Stop(context.Background())
+		require.NoError(t, err)
+
+		expectedCalls := []string{
+			"start database", "start cache", "start server",
+			"stop server", "stop cache", "stop database",
+		}
+		assert.Equal(t, expectedCalls, recorder.calls)
+
+		// Components are stopped only once
+		err = l.Stop(context.Background())
+		require.NoError(t, err)
+		assert.Len(t, recorder.calls, len(expectedCalls))
+	})
+
+	t.Run("start failure rolls back", func(t *testing.T) {
+		t.Parallel()
+
+		errTest := errors.New("test error")
+		recorder := new(callRecorder)
+		l := NewOrder("order")
+		l.Add(
+			recorder.newComponent("database", nil),
+			recorder.newComponent("cache", nil),
+			recorder.newComponent("server", errTest),
+			recorder.newComponent("worker", nil),
+		)
+
+		err := l.Start(context.Background())
+		require.Error(t, err)
+		assert.ErrorIs(t, err, errTest)
+		assert.EqualError(t, err, "starting server: test error")
+
+		expectedCalls := []string{
+			"start database", "start cache", "start server",
+			"stop cache", "stop database",
+		}
+		assert.Equal(t, expectedCalls, recorder.calls)
+
+		err = l.Stop(context.Background())
+		require.NoError(t, err)
+		assert.Len(t, recorder.calls, len(expectedCalls))
+	})
+
+	t.Run("rollback failure", func(t *testing.T) {
+		t.Parallel()
+
+		errStart := errors.New("start error")
+		errStop := errors.New("stop error")
+
+		database := NewFunc("database", func(ctx context.Context) error {
+			return nil
+		}, func(ctx context.Context) error {
+			return errStop
+		})
+		server := NewFunc("server", func(ctx context.Context) error {
+			return errStart
+		}, nil)
+
+		l := NewOrder("order")
+		l.Add(database, server)
+
+		err := l.Start(context.Background())
+
+		var startErr *StartError
+		require.ErrorAs(t, err, &startErr)
+		assert.Equal(t, "server", startErr.Name)
+		assert.Equal(t, errStart, startErr.Err)
+		assert.ErrorIs(t, startErr.RollbackErr, errStop)
+		assert.EqualError(t, err, "starting server: start error: rolling back: "+
+			"ordered shutdown timed out: database: stop error")
+	})
+}
+
+func Test_lifecycle_group(t *testing.T) {
+	t.Parallel()
+
+	t.Run("start and stop", func(t *testing.T) {
+		t.Parallel()
+
+		recorder := new(callRecorder)
+		l := NewGroup("group")
+		l.Add(
+			recorder.newComponent("A", nil),
+			recorder.newComponent("B", nil),
+		)
+
+		err := l.Start(context.Background())
+		require.NoError(t, err)
+
+		err = l.Stop(context.Background())
+		require.NoError(t, err)
+
+		assert.ElementsMatch(t, []string{"start A", "start B", "stop A", "stop B"},
+			recorder.calls)
+	})
+
+	t.Run("start failure cancels and rolls back", func(t *testing.T) {
+		t.Parallel()
+
+		errTest := errors.New("test error")
+		recorder := new(callRecorder)
+
+		slow := NewFunc("slow", func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}, func(ctx context.Context) error {
+			recorder.record("stop slow")
+			return nil
+		})
+
+		l := NewGroup("group", group.OptionTimeout(0))
+		l.Add(
+			recorder.newComponent("A", nil),
+			slow,
+			recorder.newComponent("B", errTest),
+		)
+
+		err := l.Start(context.Background())
+		require.Error(t, err)
+		assert.ErrorIs(t, err, errTest)
+		assert.EqualError(t, err, "starting B: test error")
+
+		assert.ElementsMatch(t, []string{"start A", "start B", "stop A"},
+			recorder.calls)
+	})
+}
+
+func Test_lifecycle_nested(t *testing.T) {
+	t.Parallel()
+
+	recorder := new(callRecorder)
+
+	workers := NewGroup("workers")
+	workers.Add(
+		recorder.newComponent("worker 1", nil),
+		recorder.newComponent("worker 2", nil),
+	)
+
+	root := NewOrder("root")
+	root.Add(
+		recorder.newComponent("database", nil),
+		workers,
+	)
+
+	err := root.Start(context.Background())
+	require.NoError(t, err)
+
+	rep, err := root.ShutdownReport(context.Background())
+	require.NoError(t, err)
+
+	require.Len(t, recorder.calls, 6)
+	assert.Equal(t, "start database", recorder.calls[0])
+	assert.Equal(t, "stop database", recorder.calls[5])
+
+	assert.Equal(t, report.KindOrder, rep.Kind)
+	require.Len(t, rep.Children, 2)
+	assert.Equal(t, "workers", rep.Children[0].Name)
+	assert.Equal(t, report.KindGroup, rep.Children[0].Kind)
+	assert.Len(t, rep.Children[0].Children, 2)
+	assert.Equal(t, "database", rep.Children[1].Name)
+	assert.Equal(t, report.KindHandler, rep.Children[1].Kind)
+}
+
+func Test_lifecycle_stopper(t *testing.T) {
+	t.Parallel()
+
+	errTest := errors.New("test error")
+	recorder := new(callRecorder)
+	failing := NewFunc("failing", func(ctx context.Context) error {
+		return nil
+	}, func(ctx context.Context) error {
+		recorder.record("stop failing")
+		return errTest
+	})
+	exiting := Go("exiting", func(ctx context.Context) error {
+		return errTest
+	})
+
+	l := NewOrder("order")
+	l.Add(recorder.newComponent("database", nil), failing, exiting)
+
+	parent, ok := l.(handler.Parent)
+	require.True(t, ok)
+	assert.Empty(t, parent.Children())
+
+	earlyExits := make(chan error)
+	notifier, ok := l.(handler.EarlyExitNotifier)
+	require.True(t, ok)
+	notifier.NotifyEarlyExit(earlyExits)
+
+	err := l.Start(context.Background())
+	require.NoError(t, err)
+
+	err = <-earlyExits
+	var earlyExitErr *goroutine.EarlyExitError
+	require.ErrorAs(t, err, &earlyExitErr)
+	assert.ErrorIs(t, err, errTest)
+
+	children := parent.Children()
+	require.Len(t, children, 3)
+	assert.Equal(t, "exiting", children[0].Name())
+	assert.Equal(t, "failing", children[1].Name())
+	assert.Equal(t, "database", children[2].Name())
+
+	err = l.Shutdown(context.Background())
+	assert.ErrorIs(t, err, errTest)
+
+	// The same stopper is shut down again, without stopping the
+	// components a second time.
+	err = l.Shutdown(context.Background())
+	assert.ErrorIs(t, err, errTest)
+	assert.Equal(t, []string{"start database", "stop failing", "stop database"},
+		recorder.calls)
+}
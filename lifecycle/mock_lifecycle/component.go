@@ -0,0 +1,217 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/qdm12/goshutdown/lifecycle (interfaces: Component,Lifecycle)
+
+// Package mock_lifecycle is a generated GoMock package.
+package mock_lifecycle
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	lifecycle "github.com/qdm12/goshutdown/lifecycle"
+	report "github.com/qdm12/goshutdown/report"
+)
+
+// MockComponent is a mock of Component interface.
+type MockComponent struct {
+	ctrl     *gomock.Controller
+	recorder *MockComponentMockRecorder
+}
+
+// MockComponentMockRecorder is the mock recorder for MockComponent.
+type MockComponentMockRecorder struct {
+	mock *MockComponent
+}
+
+// NewMockComponent creates a new mock instance.
+func NewMockComponent(ctrl *gomock.Controller) *MockComponent {
+	mock := &MockComponent{ctrl: ctrl}
+	mock.recorder = &MockComponentMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockComponent) EXPECT() *MockComponentMockRecorder {
+	return m.recorder
+}
+
+// IsCritical mocks base method.
+func (m *MockComponent) IsCritical() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsCritical")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsCritical indicates an expected call of IsCritical.
+func (mr *MockComponentMockRecorder) IsCritical() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsCritical", reflect.TypeOf((*MockComponent)(nil).IsCritical))
+}
+
+// Name mocks base method.
+func (m *MockComponent) Name() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Name")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Name indicates an expected call of Name.
+func (mr *MockComponentMockRecorder) Name() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Name", reflect.TypeOf((*MockComponent)(nil).Name))
+}
+
+// Start mocks base method.
+func (m *MockComponent) Start(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Start", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Start indicates an expected call of Start.
+func (mr *MockComponentMockRecorder) Start(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockComponent)(nil).Start), arg0)
+}
+
+// Stop mocks base method.
+func (m *MockComponent) Stop(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stop", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Stop indicates an expected call of Stop.
+func (mr *MockComponentMockRecorder) Stop(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockComponent)(nil).Stop), arg0)
+}
+
+// MockLifecycle is a mock of Lifecycle interface.
+type MockLifecycle struct {
+	ctrl     *gomock.Controller
+	recorder *MockLifecycleMockRecorder
+}
+
+// MockLifecycleMockRecorder is the mock recorder for MockLifecycle.
+type MockLifecycleMockRecorder struct {
+	mock *MockLifecycle
+}
+
+// NewMockLifecycle creates a new mock instance.
+func NewMockLifecycle(ctrl *gomock.Controller) *MockLifecycle {
+	mock := &MockLifecycle{ctrl: ctrl}
+	mock.recorder = &MockLifecycleMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLifecycle) EXPECT() *MockLifecycleMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockLifecycle) Add(arg0 ...lifecycle.Component) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{}
+	for _, a := range arg0 {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Add", varargs...)
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockLifecycleMockRecorder) Add(arg0 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockLifecycle)(nil).Add), arg0...)
+}
+
+// IsCritical mocks base method.
+func (m *MockLifecycle) IsCritical() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsCritical")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsCritical indicates an expected call of IsCritical.
+func (mr *MockLifecycleMockRecorder) IsCritical() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsCritical", reflect.TypeOf((*MockLifecycle)(nil).IsCritical))
+}
+
+// Name mocks base method.
+func (m *MockLifecycle) Name() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Name")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Name indicates an expected call of Name.
+func (mr *MockLifecycleMockRecorder) Name() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Name", reflect.TypeOf((*MockLifecycle)(nil).Name))
+}
+
+// Shutdown mocks base method.
+func (m *MockLifecycle) Shutdown(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Shutdown", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Shutdown indicates an expected call of Shutdown.
+func (mr *MockLifecycleMockRecorder) Shutdown(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Shutdown", reflect.TypeOf((*MockLifecycle)(nil).Shutdown), arg0)
+}
+
+// ShutdownReport mocks base method.
+func (m *MockLifecycle) ShutdownReport(arg0 context.Context) (*report.Report, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ShutdownReport", arg0)
+	ret0, _ := ret[0].(*report.Report)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ShutdownReport indicates an expected call of ShutdownReport.
+func (mr *MockLifecycleMockRecorder) ShutdownReport(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShutdownReport", reflect.TypeOf((*MockLifecycle)(nil).ShutdownReport), arg0)
+}
+
+// Start mocks base method.
+func (m *MockLifecycle) Start(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Start", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Start indicates an expected call of Start.
+func (mr *MockLifecycleMockRecorder) Start(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockLifecycle)(nil).Start), arg0)
+}
+
+// Stop mocks base method.
+func (m *MockLifecycle) Stop(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stop", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Stop indicates an expected call of Stop.
+func (mr *MockLifecycleMockRecorder) Stop(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockLifecycle)(nil).Stop), arg0)
+}
@@ -0,0 +1,10 @@
+package lifecycle
+
+type Option func(s *settings)
+
+// OptionCritical marks the component as critical.
+func OptionCritical() Option {
+	return func(s *settings) {
+		s.critical = true
+	}
+}
@@ -0,0 +1,12 @@
+package lifecycle
+
+// settings defines configuration settings for a component.
+type settings struct {
+	// critical can be set to true to indicate the shutdown process should exit if
+	// this component cannot be stopped.
+	critical bool
+}
+
+func newSettings() settings {
+	return settings{}
+}
@@ -0,0 +1,42 @@
+package logging
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingLogger struct {
+	records []string
+}
+
+func (l *recordingLogger) Debug(msg string, _ ...interface{}) {
+	l.records = append(l.records, "DEBUG "+msg)
+}
+
+func (l *recordingLogger) Info(msg string, _ ...interface{}) {
+	l.records = append(l.records, "INFO "+msg)
+}
+
+func (l *recordingLogger) Warn(msg string, _ ...interface{}) {
+	l.records = append(l.records, "WARN "+msg)
+}
+
+func (l *recordingLogger) Error(msg string, _ ...interface{}) {
+	l.records = append(l.records, "ERROR "+msg)
+}
+
+func Test_Observer_log(t *testing.T) {
+	t.Parallel()
+
+	logger := new(recordingLogger)
+	observer := New(logger)
+
+	observer.log(LevelDebug, "a", nil)
+	observer.log(LevelInfo, "b", nil)
+	observer.log(LevelWarn, "c", nil)
+	observer.log(LevelError, "d", nil)
+
+	expected := []string{"DEBUG a", "INFO b", "WARN c", "ERROR d"}
+	assert.Equal(t, expected, logger.records)
+}
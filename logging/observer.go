@@ -0,0 +1,139 @@
+// Package logging defines a shutdown events observer logging
+// structured records with a leveled key-value logger, such as
+// a *slog.Logger.
+package logging
+
+import (
+	"github.com/qdm12/goshutdown/event"
+	"github.com/qdm12/goshutdown/graph"
+	"github.com/qdm12/goshutdown/group"
+	"github.com/qdm12/goshutdown/order"
+)
+
+// Attribute keys of the records logged.
+const (
+	KeyHandler  = "handler"
+	KeyPath     = "path"
+	KeyCritical = "critical"
+	KeyElapsed  = "elapsed"
+	KeyError    = "error"
+)
+
+// Logger is the leveled key-value logger used by the Observer,
+// which is notably implemented by *slog.Logger.
+type Logger interface {
+	Debug(msg string, args ...interface{})
+	Info(msg string, args ...interface{})
+	Warn(msg string, args ...interface{})
+	Error(msg string, args ...interface{})
+}
+
+// Level is the level to log an event at.
+type Level int
+
+// Levels to log events at.
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+// Observer is an event.Observer logging each shutdown event as a
+// structured record with attributes for the handler name, path in the
+// tree, critical flag, and elapsed time and error if set.
+type Observer struct {
+	logger   Logger
+	settings settings
+}
+
+// New creates a logging observer using the logger given.
+func New(logger Logger, options ...Option) *Observer {
+	settings := newSettings()
+	for _, option := range options {
+		option(&settings)
+	}
+
+	return &Observer{
+		logger:   logger,
+		settings: settings,
+	}
+}
+
+// Observe logs the event given.
+func (o *Observer) Observe(e event.Event) {
+	level := o.settings.levels[e.Type]
+	if e.Err != nil {
+		level = o.settings.errorLevel
+	}
+
+	const maxArgs = 10
+	args := make([]interface{}, 0, maxArgs)
+	args = append(args,
+		KeyHandler, e.Name,
+		KeyPath, e.Path,
+		KeyCritical, e.Critical,
+	)
+	switch e.Type { //nolint:exhaustive
+	case event.TypeHandlerSucceeded, event.TypeHandlerFailed,
+		event.TypeShutdownFinished:
+		args = append(args, KeyElapsed, e.Duration)
+	}
+	if e.Err != nil {
+		args = append(args, KeyError, e.Err.Error())
+	}
+
+	o.log(level, message(e.Type), args)
+}
+
+func (o *Observer) log(level Level, msg string, args []interface{}) {
+	switch level {
+	case LevelDebug:
+		o.logger.Debug(msg, args...)
+	case LevelInfo:
+		o.logger.Info(msg, args...)
+	case LevelWarn:
+		o.logger.Warn(msg, args...)
+	default:
+		o.logger.Error(msg, args...)
+	}
+}
+
+func message(eventType event.Type) string {
+	switch eventType {
+	case event.TypeShutdownStarted:
+		return "shutdown started"
+	case event.TypeHandlerStarted:
+		return "handler shutdown started"
+	case event.TypeHandlerSucceeded:
+		return "handler shutdown succeeded"
+	case event.TypeHandlerFailed:
+		return "handler shutdown failed"
+	case event.TypeHandlerSkipped:
+		return "handler shutdown skipped"
+	case event.TypeCriticalAbort:
+		return "critical handler failed, aborting shutdown"
+	case event.TypeShutdownFinished:
+		return "shutdown finished"
+	default:
+		return string(eventType)
+	}
+}
+
+// OrderOption returns an order option logging the shutdown events
+// of the order and of its nested handlers, including goroutine handlers.
+func (o *Observer) OrderOption() order.Option {
+	return order.OptionObserver(o)
+}
+
+// GroupOption returns a group option logging the shutdown events
+// of the group and of its nested handlers, including goroutine handlers.
+func (o *Observer) GroupOption() group.Option {
+	return group.OptionObserver(o)
+}
+
+// GraphOption returns a graph option logging the shutdown events
+// of the graph and of its nested handlers, including goroutine handlers.
+func (o *Observer) GraphOption() graph.Option {
+	return graph.OptionObserver(o)
+}
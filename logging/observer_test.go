@@ -0,0 +1,175 @@
+//go:build go1.21
+// +build go1.21
+
+package logging
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/qdm12/goshutdown/event"
+	"github.com/qdm12/goshutdown/goroutine"
+	"github.com/qdm12/goshutdown/group"
+	"github.com/qdm12/goshutdown/order"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var _ Logger = (*slog.Logger)(nil)
+
+// newTestLogger returns a text logger without time attribute,
+// writing to the buffer returned.
+func newTestLogger(level slog.Level) (logger *slog.Logger, buffer *bytes.Buffer) {
+	buffer = new(bytes.Buffer)
+	handler := slog.NewTextHandler(buffer, &slog.HandlerOptions{
+		Level: level,
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey && len(groups) == 0 {
+				return slog.Attr{}
+			}
+			return a
+		},
+	})
+	return slog.New(handler), buffer
+}
+
+func Test_New(t *testing.T) {
+	t.Parallel()
+
+	logger, _ := newTestLogger(slog.LevelInfo)
+
+	observer := New(logger,
+		OptionLevel(event.TypeHandlerStarted, LevelInfo),
+		OptionErrorLevel(LevelWarn))
+
+	expectedSettings := newSettings()
+	expectedSettings.levels[event.TypeHandlerStarted] = LevelInfo
+	expectedSettings.errorLevel = LevelWarn
+	assert.Equal(t, &Observer{
+		logger:   logger,
+		settings: expectedSettings,
+	}, observer)
+}
+
+func Test_Observer_Observe(t *testing.T) {
+	t.Parallel()
+
+	errTest := errors.New("test error")
+
+	testCases := map[string]struct {
+		loggerLevel slog.Level
+		event       event.Event
+		line        string
+	}{
+		"shutdown started": {
+			event: event.Event{
+				Type: event.TypeShutdownStarted,
+				Path: "order",
+				Name: "order",
+			},
+			line: `level=INFO msg="shutdown started" handler=order path=order critical=false`,
+		},
+		"handler started filtered": {
+			event: event.Event{
+				Type: event.TypeHandlerStarted,
+				Path: "order/A",
+				Name: "A",
+			},
+		},
+		"handler started": {
+			loggerLevel: slog.LevelDebug,
+			event: event.Event{
+				Type: event.TypeHandlerStarted,
+				Path: "order/A",
+				Name: "A",
+			},
+			line: `level=DEBUG msg="handler shutdown started" handler=A path=order/A critical=false`,
+		},
+		"handler succeeded": {
+			event: event.Event{
+				Type:     event.TypeHandlerSucceeded,
+				Path:     "order/A",
+				Name:     "A",
+				Critical: true,
+				Duration: time.Second,
+			},
+			line: `level=INFO msg="handler shutdown succeeded" handler=A path=order/A critical=true elapsed=1s`,
+		},
+		"handler failed": {
+			event: event.Event{
+				Type:     event.TypeHandlerFailed,
+				Path:     "order/A",
+				Name:     "A",
+				Duration: time.Second,
+				Err:      errTest,
+			},
+			line: `level=ERROR msg="handler shutdown failed" handler=A path=order/A critical=false elapsed=1s error="test error"`, //nolint:lll
+		},
+		"handler skipped": {
+			event: event.Event{
+				Type: event.TypeHandlerSkipped,
+				Path: "order/A",
+				Name: "A",
+			},
+			line: `level=WARN msg="handler shutdown skipped" handler=A path=order/A critical=false`,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			logger, buffer := newTestLogger(testCase.loggerLevel)
+			observer := New(logger)
+
+			observer.Observe(testCase.event)
+
+			assert.Equal(t, testCase.line, strings.TrimSuffix(buffer.String(), "\n"))
+		})
+	}
+}
+
+func Test_Observer_options(t *testing.T) {
+	t.Parallel()
+
+	logger, buffer := newTestLogger(slog.LevelInfo)
+	observer := New(logger)
+
+	workers := group.New("workers", observer.GroupOption())
+	handler, ctx, done := goroutine.New("worker")
+	go func() {
+		<-ctx.Done()
+		close(done)
+	}()
+	workers.Add(handler)
+
+	root := order.New("order", observer.OrderOption())
+	root.Append(workers)
+
+	err := root.Shutdown(context.Background())
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSuffix(buffer.String(), "\n"), "\n")
+	expectedPrefixes := []string{
+		`level=INFO msg="shutdown started" handler=order path=order`,
+		// group events are logged once by each of the two observers
+		`level=INFO msg="shutdown started" handler=workers path=order/workers`,
+		`level=INFO msg="shutdown started" handler=workers path=order/workers`,
+		`level=INFO msg="handler shutdown succeeded" handler=worker path=order/workers/worker`,
+		`level=INFO msg="handler shutdown succeeded" handler=worker path=order/workers/worker`,
+		`level=INFO msg="shutdown finished" handler=workers path=order/workers`,
+		`level=INFO msg="shutdown finished" handler=workers path=order/workers`,
+		`level=INFO msg="handler shutdown succeeded" handler=workers path=order/workers`,
+		`level=INFO msg="shutdown finished" handler=order path=order`,
+	}
+	require.Len(t, lines, len(expectedPrefixes))
+	for i, prefix := range expectedPrefixes {
+		assert.True(t, strings.HasPrefix(lines[i], prefix), lines[i])
+	}
+}
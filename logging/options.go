@@ -0,0 +1,21 @@
+package logging
+
+import "github.com/qdm12/goshutdown/event"
+
+type Option func(s *settings)
+
+// OptionLevel sets the level to log events of the type given at,
+// for events without an error.
+func OptionLevel(eventType event.Type, level Level) Option {
+	return func(s *settings) {
+		s.levels[eventType] = level
+	}
+}
+
+// OptionErrorLevel sets the level to log events with an error at,
+// regardless of their type. Note it defaults to LevelError.
+func OptionErrorLevel(level Level) Option {
+	return func(s *settings) {
+		s.errorLevel = level
+	}
+}
@@ -0,0 +1,30 @@
+package logging
+
+import "github.com/qdm12/goshutdown/event"
+
+// settings defines configuration settings for the logging observer.
+type settings struct {
+	// levels are the levels to log events at, for each event type.
+	// They default to LevelDebug for TypeHandlerStarted events,
+	// LevelWarn for TypeHandlerSkipped events and LevelInfo
+	// for the other event types.
+	levels map[event.Type]Level
+	// errorLevel is the level to log events with an error at,
+	// regardless of their type. It defaults to LevelError.
+	errorLevel Level
+}
+
+func newSettings() settings {
+	return settings{
+		levels: map[event.Type]Level{
+			event.TypeShutdownStarted:  LevelInfo,
+			event.TypeHandlerStarted:   LevelDebug,
+			event.TypeHandlerSucceeded: LevelInfo,
+			event.TypeHandlerFailed:    LevelInfo,
+			event.TypeHandlerSkipped:   LevelWarn,
+			event.TypeCriticalAbort:    LevelInfo,
+			event.TypeShutdownFinished: LevelInfo,
+		},
+		errorLevel: LevelError,
+	}
+}
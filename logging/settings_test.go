@@ -0,0 +1,28 @@
+package logging
+
+import (
+	"testing"
+
+	"github.com/qdm12/goshutdown/event"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_newSettings(t *testing.T) {
+	t.Parallel()
+
+	s := newSettings()
+
+	expected := settings{
+		levels: map[event.Type]Level{
+			event.TypeShutdownStarted:  LevelInfo,
+			event.TypeHandlerStarted:   LevelDebug,
+			event.TypeHandlerSucceeded: LevelInfo,
+			event.TypeHandlerFailed:    LevelInfo,
+			event.TypeHandlerSkipped:   LevelWarn,
+			event.TypeCriticalAbort:    LevelInfo,
+			event.TypeShutdownFinished: LevelInfo,
+		},
+		errorLevel: LevelError,
+	}
+	assert.Equal(t, expected, s)
+}
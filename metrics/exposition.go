@@ -0,0 +1,147 @@
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// WritePrometheus writes the metrics in the Prometheus
+// text exposition format to the writer given.
+func (o *Observer) WritePrometheus(w io.Writer) (err error) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	buffered := bufio.NewWriter(w)
+	namespace := o.settings.namespace
+
+	o.writeHistograms(buffered, namespace+"_handler_shutdown_duration_seconds",
+		"Duration of the handler shutdowns in seconds.")
+	writeCounters(buffered, namespace+"_handler_shutdown_failures_total",
+		"Number of handler shutdowns which failed.", o.failures)
+	writeCounters(buffered, namespace+"_handler_shutdown_timeouts_total",
+		"Number of handler shutdowns which timed out.", o.timeouts)
+	writeCounters(buffered, namespace+"_critical_aborts_total",
+		"Number of shutdowns aborted because of a critical handler failure.",
+		o.criticalAborts)
+	writeGauges(buffered, namespace+"_handler_shutdowns_in_progress",
+		"Number of handler shutdowns in progress.", o.inProgress)
+
+	err = buffered.Flush()
+	if err != nil {
+		return fmt.Errorf("writing metrics: %w", err)
+	}
+	return nil
+}
+
+func (o *Observer) writeHistograms(w io.Writer, name, help string) {
+	writeHeader(w, name, help, "histogram")
+	for _, path := range sortedKeys(o.durations) {
+		h := o.durations[path]
+		label := pathLabel(path)
+		for i, bound := range h.bounds {
+			fmt.Fprintf(w, "%s_bucket{%s,le=\"%s\"} %d\n",
+				name, label, formatFloat(bound), h.counts[i])
+		}
+		fmt.Fprintf(w, "%s_bucket{%s,le=\"+Inf\"} %d\n", name, label, h.count)
+		fmt.Fprintf(w, "%s_sum{%s} %s\n", name, label, formatFloat(h.sum))
+		fmt.Fprintf(w, "%s_count{%s} %d\n", name, label, h.count)
+	}
+}
+
+func writeCounters(w io.Writer, name, help string, counters map[string]uint64) {
+	writeHeader(w, name, help, "counter")
+	for _, path := range sortedKeys(counters) {
+		fmt.Fprintf(w, "%s{%s} %d\n", name, pathLabel(path), counters[path])
+	}
+}
+
+func writeGauges(w io.Writer, name, help string, gauges map[string]int64) {
+	writeHeader(w, name, help, "gauge")
+	for _, path := range sortedKeys(gauges) {
+		fmt.Fprintf(w, "%s{%s} %d\n", name, pathLabel(path), gauges[path])
+	}
+}
+
+func writeHeader(w io.Writer, name, help, metricType string) {
+	fmt.Fprintf(w, "# HELP %s %s\n", name, help)
+	fmt.Fprintf(w, "# TYPE %s %s\n", name, metricType)
+}
+
+// sortedKeys returns the sorted keys of the map given,
+// which must have string keys.
+func sortedKeys(m interface{}) (keys []string) {
+	mapValue := reflect.ValueOf(m)
+	keys = make([]string, 0, mapValue.Len())
+	for _, key := range mapValue.MapKeys() {
+		keys = append(keys, key.String())
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+var labelValueReplacer = strings.NewReplacer( //nolint:gochecknoglobals
+	`\`, `\\`,
+	"\n", `\n`,
+	`"`, `\"`,
+)
+
+func pathLabel(path string) string {
+	return `path="` + labelValueReplacer.Replace(path) + `"`
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+// ServeHTTP serves the metrics in the Prometheus text exposition format.
+func (o *Observer) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_ = o.WritePrometheus(w)
+}
+
+// WriteFile writes the metrics in the Prometheus text exposition format
+// to the file path given, for example at program exit for the textfile
+// collector of the node exporter. The file is written to a temporary file
+// first, and then renamed, so the collector never reads a partial file.
+func (o *Observer) WriteFile(path string) (err error) {
+	const perm = 0o644
+	file, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("creating temporary file: %w", err)
+	}
+	tempPath := file.Name()
+
+	err = o.WritePrometheus(file)
+	if err != nil {
+		_ = file.Close()
+		_ = os.Remove(tempPath)
+		return err
+	}
+
+	err = file.Close()
+	if err != nil {
+		_ = os.Remove(tempPath)
+		return fmt.Errorf("closing temporary file: %w", err)
+	}
+
+	err = os.Chmod(tempPath, perm)
+	if err != nil {
+		_ = os.Remove(tempPath)
+		return fmt.Errorf("setting file permissions: %w", err)
+	}
+
+	err = os.Rename(tempPath, path)
+	if err != nil {
+		_ = os.Remove(tempPath)
+		return fmt.Errorf("renaming temporary file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package metrics
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/qdm12/goshutdown/event"
+	"github.com/qdm12/goshutdown/report"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestObserver() *Observer {
+	observer := New(OptionNamespace("test"), OptionBuckets([]float64{0.1, 1}))
+	events := []event.Event{
+		{Type: event.TypeShutdownStarted, Path: "root", Name: "root"},
+		{Type: event.TypeHandlerStarted, Path: "root/A", Name: "A"},
+		{Type: event.TypeHandlerStarted, Path: `root/"B"`, Name: `"B"`},
+		{Type: event.TypeHandlerFailed, Path: `root/"B"`, Name: `"B"`,
+			Duration: 250 * time.Millisecond, Status: report.StatusTimeout,
+			Err: errors.New("timed out")},
+		{Type: event.TypeCriticalAbort, Path: `root/"B"`, Name: `"B"`},
+	}
+	for _, e := range events {
+		observer.Observe(e)
+	}
+	return observer
+}
+
+const expectedExposition = `# HELP test_handler_shutdown_duration_seconds Duration of the handler shutdowns in seconds.
+# TYPE test_handler_shutdown_duration_seconds histogram
+test_handler_shutdown_duration_seconds_bucket{path="root/\"B\"",le="0.1"} 0
+test_handler_shutdown_duration_seconds_bucket{path="root/\"B\"",le="1"} 1
+test_handler_shutdown_duration_seconds_bucket{path="root/\"B\"",le="+Inf"} 1
+test_handler_shutdown_duration_seconds_sum{path="root/\"B\""} 0.25
+test_handler_shutdown_duration_seconds_count{path="root/\"B\""} 1
+# HELP test_handler_shutdown_failures_total Number of handler shutdowns which failed.
+# TYPE test_handler_shutdown_failures_total counter
+test_handler_shutdown_failures_total{path="root/\"B\""} 1
+# HELP test_handler_shutdown_timeouts_total Number of handler shutdowns which timed out.
+# TYPE test_handler_shutdown_timeouts_total counter
+test_handler_shutdown_timeouts_total{path="root/\"B\""} 1
+# HELP test_critical_aborts_total Number of shutdowns aborted because of a critical handler failure.
+# TYPE test_critical_aborts_total counter
+test_critical_aborts_total{path="root/\"B\""} 1
+# HELP test_handler_shutdowns_in_progress Number of handler shutdowns in progress.
+# TYPE test_handler_shutdowns_in_progress gauge
+test_handler_shutdowns_in_progress{path="root"} 1
+test_handler_shutdowns_in_progress{path="root/\"B\""} 0
+test_handler_shutdowns_in_progress{path="root/A"} 1
+`
+
+func Test_Observer_WritePrometheus(t *testing.T) {
+	t.Parallel()
+
+	observer := newTestObserver()
+
+	buffer := new(bytes.Buffer)
+	err := observer.WritePrometheus(buffer)
+
+	require.NoError(t, err)
+	assert.Equal(t, expectedExposition, buffer.String())
+}
+
+func Test_Observer_ServeHTTP(t *testing.T) {
+	t.Parallel()
+
+	observer := newTestObserver()
+
+	server := httptest.NewServer(observer)
+	t.Cleanup(server.Close)
+
+	response, err := http.Get(server.URL) //nolint:noctx
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = response.Body.Close() })
+
+	body := new(bytes.Buffer)
+	_, err = body.ReadFrom(response.Body)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Equal(t, "text/plain; version=0.0.4; charset=utf-8",
+		response.Header.Get("Content-Type"))
+	assert.Equal(t, expectedExposition, body.String())
+}
+
+func Test_Observer_WriteFile(t *testing.T) {
+	t.Parallel()
+
+	observer := newTestObserver()
+	directory := t.TempDir()
+	path := filepath.Join(directory, "goshutdown.prom")
+
+	err := observer.WriteFile(path)
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, expectedExposition, string(data))
+
+	entries, err := os.ReadDir(directory)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+
+	t.Run("missing directory", func(t *testing.T) {
+		t.Parallel()
+
+		err := observer.WriteFile(filepath.Join(directory, "missing", "file.prom"))
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,135 @@
+// Package metrics defines a shutdown events observer recording metrics,
+// which can be rendered in the Prometheus text exposition format.
+package metrics
+
+import (
+	"sync"
+
+	"github.com/qdm12/goshutdown/event"
+	"github.com/qdm12/goshutdown/graph"
+	"github.com/qdm12/goshutdown/group"
+	"github.com/qdm12/goshutdown/order"
+	"github.com/qdm12/goshutdown/report"
+)
+
+// Observer is an event.Observer recording, for each handler path,
+// the shutdown duration histogram, the shutdown failures and timeouts
+// counters, the critical aborts counter and the shutdowns in progress
+// gauge. It should be set on the root handler only, since the events
+// of nested handlers are propagated to the observers of their parents.
+type Observer struct {
+	settings       settings
+	mutex          sync.Mutex
+	durations      map[string]*histogram
+	failures       map[string]uint64
+	timeouts       map[string]uint64
+	criticalAborts map[string]uint64
+	inProgress     map[string]int64
+}
+
+// New creates a metrics observer.
+func New(options ...Option) *Observer {
+	settings := newSettings()
+	for _, option := range options {
+		option(&settings)
+	}
+
+	return &Observer{
+		settings:       settings,
+		durations:      make(map[string]*histogram),
+		failures:       make(map[string]uint64),
+		timeouts:       make(map[string]uint64),
+		criticalAborts: make(map[string]uint64),
+		inProgress:     make(map[string]int64),
+	}
+}
+
+// Observe records the event given. Shutdown started and finished events
+// are only recorded for the root handler, since the same shutdowns of the
+// nested handlers are recorded with the handler events of their parent.
+func (o *Observer) Observe(e event.Event) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	isRoot := e.Path == e.Name
+
+	switch e.Type {
+	case event.TypeShutdownStarted:
+		if isRoot {
+			o.inProgress[e.Path]++
+		}
+	case event.TypeHandlerStarted:
+		o.inProgress[e.Path]++
+	case event.TypeShutdownFinished:
+		if isRoot {
+			o.recordFinished(e)
+		}
+	case event.TypeHandlerSucceeded, event.TypeHandlerFailed:
+		o.recordFinished(e)
+	case event.TypeCriticalAbort:
+		o.criticalAborts[e.Path]++
+	case event.TypeHandlerSkipped:
+	}
+}
+
+func (o *Observer) recordFinished(e event.Event) {
+	o.inProgress[e.Path]--
+
+	h, ok := o.durations[e.Path]
+	if !ok {
+		h = newHistogram(o.settings.buckets)
+		o.durations[e.Path] = h
+	}
+	h.observe(e.Duration.Seconds())
+
+	if e.Err != nil {
+		o.failures[e.Path]++
+	}
+	if e.Status == report.StatusTimeout {
+		o.timeouts[e.Path]++
+	}
+}
+
+// OrderOption returns an order option recording metrics for the
+// shutdown of the order and of its nested handlers.
+func (o *Observer) OrderOption() order.Option {
+	return order.OptionObserver(o)
+}
+
+// GroupOption returns a group option recording metrics for the
+// shutdown of the group and of its nested handlers.
+func (o *Observer) GroupOption() group.Option {
+	return group.OptionObserver(o)
+}
+
+// GraphOption returns a graph option recording metrics for the
+// shutdown of the graph and of its nested handlers.
+func (o *Observer) GraphOption() graph.Option {
+	return graph.OptionObserver(o)
+}
+
+// histogram is a cumulative histogram.
+type histogram struct {
+	bounds []float64
+	// counts are the cumulative counts for each of the bounds.
+	counts []uint64
+	sum    float64
+	count  uint64
+}
+
+func newHistogram(bounds []float64) *histogram {
+	return &histogram{
+		bounds: bounds,
+		counts: make([]uint64, len(bounds)),
+	}
+}
+
+func (h *histogram) observe(value float64) {
+	for i, bound := range h.bounds {
+		if value <= bound {
+			h.counts[i]++
+		}
+	}
+	h.sum += value
+	h.count++
+}
@@ -0,0 +1,123 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/qdm12/goshutdown/event"
+	"github.com/qdm12/goshutdown/goroutine"
+	"github.com/qdm12/goshutdown/order"
+	"github.com/qdm12/goshutdown/report"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_New(t *testing.T) {
+	t.Parallel()
+
+	observer := New(OptionNamespace("app"), OptionBuckets([]float64{1, 2}))
+
+	assert.Equal(t, &Observer{
+		settings: settings{
+			namespace: "app",
+			buckets:   []float64{1, 2},
+		},
+		durations:      map[string]*histogram{},
+		failures:       map[string]uint64{},
+		timeouts:       map[string]uint64{},
+		criticalAborts: map[string]uint64{},
+		inProgress:     map[string]int64{},
+	}, observer)
+}
+
+func Test_Observer_Observe(t *testing.T) {
+	t.Parallel()
+
+	errTest := errors.New("test error")
+
+	observer := New(OptionBuckets([]float64{0.1, 1}))
+	events := []event.Event{
+		{Type: event.TypeShutdownStarted, Path: "root", Name: "root"},
+		{Type: event.TypeHandlerStarted, Path: "root/A", Name: "A"},
+		{Type: event.TypeHandlerSucceeded, Path: "root/A", Name: "A",
+			Duration: 50 * time.Millisecond, Status: report.StatusSuccess},
+		{Type: event.TypeHandlerStarted, Path: "root/B", Name: "B"},
+		// Nested scope events are not recorded since the parent
+		// records the handler events for the same shutdown.
+		{Type: event.TypeShutdownStarted, Path: "root/B", Name: "B"},
+		{Type: event.TypeShutdownFinished, Path: "root/B", Name: "B",
+			Duration: 500 * time.Millisecond, Status: report.StatusTimeout, Err: errTest},
+		{Type: event.TypeHandlerFailed, Path: "root/B", Name: "B",
+			Duration: 500 * time.Millisecond, Status: report.StatusTimeout, Err: errTest},
+		{Type: event.TypeCriticalAbort, Path: "root/B", Name: "B"},
+		{Type: event.TypeHandlerSkipped, Path: "root/C", Name: "C"},
+		{Type: event.TypeShutdownFinished, Path: "root", Name: "root",
+			Duration: 2 * time.Second, Status: report.StatusFailure, Err: errTest},
+	}
+	for _, e := range events {
+		observer.Observe(e)
+	}
+
+	expectedDurations := map[string]*histogram{
+		"root": {
+			bounds: []float64{0.1, 1},
+			counts: []uint64{0, 0},
+			sum:    2,
+			count:  1,
+		},
+		"root/A": {
+			bounds: []float64{0.1, 1},
+			counts: []uint64{1, 1},
+			sum:    0.05,
+			count:  1,
+		},
+		"root/B": {
+			bounds: []float64{0.1, 1},
+			counts: []uint64{0, 1},
+			sum:    0.5,
+			count:  1,
+		},
+	}
+	assert.Equal(t, expectedDurations, observer.durations)
+	assert.Equal(t, map[string]uint64{"root": 1, "root/B": 1}, observer.failures)
+	assert.Equal(t, map[string]uint64{"root/B": 1}, observer.timeouts)
+	assert.Equal(t, map[string]uint64{"root/B": 1}, observer.criticalAborts)
+	assert.Equal(t, map[string]int64{"root": 0, "root/A": 0, "root/B": 0},
+		observer.inProgress)
+}
+
+func Test_Observer_order(t *testing.T) {
+	t.Parallel()
+
+	observer := New()
+
+	handlerA, _, doneA := goroutine.New("A")
+	go func() { close(doneA) }()
+
+	handlerB, ctxB, doneB := goroutine.New("B", goroutine.OptionTimeout(time.Millisecond))
+	go func() {
+		<-ctxB.Done()
+		time.Sleep(50 * time.Millisecond)
+		close(doneB)
+	}()
+
+	o := order.New("root", observer.OrderOption())
+	o.Append(handlerA, handlerB)
+
+	err := o.Shutdown(context.Background())
+	require.Error(t, err)
+
+	observer.mutex.Lock()
+	defer observer.mutex.Unlock()
+	assert.Equal(t, uint64(1), observer.durations["root"].count)
+	assert.Equal(t, uint64(1), observer.durations["root/A"].count)
+	assert.Equal(t, uint64(1), observer.durations["root/B"].count)
+	assert.Equal(t, map[string]uint64{"root": 1, "root/B": 1}, observer.failures)
+	// The order error wraps the timeout error of B
+	assert.Equal(t, map[string]uint64{"root": 1, "root/B": 1}, observer.timeouts)
+	assert.Empty(t, observer.criticalAborts)
+	assert.Equal(t, map[string]int64{"root": 0, "root/A": 0, "root/B": 0},
+		observer.inProgress)
+}
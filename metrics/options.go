@@ -0,0 +1,19 @@
+package metrics
+
+type Option func(s *settings)
+
+// OptionNamespace sets the prefix of the metric names.
+// Note it defaults to goshutdown.
+func OptionNamespace(namespace string) Option {
+	return func(s *settings) {
+		s.namespace = namespace
+	}
+}
+
+// OptionBuckets sets the upper bounds in seconds of the buckets
+// of the shutdown duration histograms, in increasing order.
+func OptionBuckets(buckets []float64) Option {
+	return func(s *settings) {
+		s.buckets = buckets
+	}
+}
@@ -0,0 +1,19 @@
+package metrics
+
+// settings defines configuration settings for the metrics observer.
+type settings struct {
+	// namespace is the prefix of the metric names.
+	// It defaults to goshutdown if left unset.
+	namespace string
+	// buckets are the upper bounds in seconds of the buckets of the
+	// shutdown duration histograms, in increasing order. They default
+	// to 5ms, 10ms, 25ms, 50ms, 100ms, 250ms, 500ms, 1s, 2.5s, 5s and 10s.
+	buckets []float64
+}
+
+func newSettings() settings {
+	return settings{
+		namespace: "goshutdown",
+		buckets:   []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10},
+	}
+}
@@ -2,9 +2,17 @@ package mock
 
 import (
 	"github.com/golang/mock/gomock"
+	"github.com/qdm12/goshutdown/drain/mock_drain"
 	"github.com/qdm12/goshutdown/goroutine/mock_goroutine"
+	"github.com/qdm12/goshutdown/graph/mock_graph"
 	"github.com/qdm12/goshutdown/group/mock_group"
+	"github.com/qdm12/goshutdown/handlers/closer/mock_closer"
+	"github.com/qdm12/goshutdown/handlers/httpserver/mock_httpserver"
+	"github.com/qdm12/goshutdown/handlers/process/mock_process"
+	"github.com/qdm12/goshutdown/hook/mock_hook"
+	"github.com/qdm12/goshutdown/lifecycle/mock_lifecycle"
 	"github.com/qdm12/goshutdown/order/mock_order"
+	"github.com/qdm12/goshutdown/supervisor/mock_supervisor"
 )
 
 // NewGoRoutineMockHandler creates a new mock_goroutine.MockHandler.
@@ -12,6 +20,21 @@ func NewGoRoutineMockHandler(ctrl *gomock.Controller) *mock_goroutine.MockHandle
 	return mock_goroutine.NewMockHandler(ctrl)
 }
 
+// NewGoRoutineTwoPhaseMockHandler creates a new mock_goroutine.MockTwoPhaseHandler.
+func NewGoRoutineTwoPhaseMockHandler(ctrl *gomock.Controller) *mock_goroutine.MockTwoPhaseHandler {
+	return mock_goroutine.NewMockTwoPhaseHandler(ctrl)
+}
+
+// NewGoRoutineReadyMockHandler creates a new mock_goroutine.MockReadyHandler.
+func NewGoRoutineReadyMockHandler(ctrl *gomock.Controller) *mock_goroutine.MockReadyHandler {
+	return mock_goroutine.NewMockReadyHandler(ctrl)
+}
+
+// NewDrainMockHandler creates a new mock_drain.MockHandler.
+func NewDrainMockHandler(ctrl *gomock.Controller) *mock_drain.MockHandler {
+	return mock_drain.NewMockHandler(ctrl)
+}
+
 // NewGroupMockHandler creates a new mock_group.MockHandler.
 func NewGroupMockHandler(ctrl *gomock.Controller) *mock_group.MockHandler {
 	return mock_group.NewMockHandler(ctrl)
@@ -21,3 +44,38 @@ func NewGroupMockHandler(ctrl *gomock.Controller) *mock_group.MockHandler {
 func NewOrderMockHandler(ctrl *gomock.Controller) *mock_order.MockHandler {
 	return mock_order.NewMockHandler(ctrl)
 }
+
+// NewGraphMockHandler creates a new mock_graph.MockHandler.
+func NewGraphMockHandler(ctrl *gomock.Controller) *mock_graph.MockHandler {
+	return mock_graph.NewMockHandler(ctrl)
+}
+
+// NewSupervisorMockHandler creates a new mock_supervisor.MockHandler.
+func NewSupervisorMockHandler(ctrl *gomock.Controller) *mock_supervisor.MockHandler {
+	return mock_supervisor.NewMockHandler(ctrl)
+}
+
+// NewHTTPServerMockHandler creates a new mock_httpserver.MockHandler.
+func NewHTTPServerMockHandler(ctrl *gomock.Controller) *mock_httpserver.MockHandler {
+	return mock_httpserver.NewMockHandler(ctrl)
+}
+
+// NewCloserMockHandler creates a new mock_closer.MockHandler.
+func NewCloserMockHandler(ctrl *gomock.Controller) *mock_closer.MockHandler {
+	return mock_closer.NewMockHandler(ctrl)
+}
+
+// NewProcessMockHandler creates a new mock_process.MockHandler.
+func NewProcessMockHandler(ctrl *gomock.Controller) *mock_process.MockHandler {
+	return mock_process.NewMockHandler(ctrl)
+}
+
+// NewHookMockHandler creates a new mock_hook.MockHandler.
+func NewHookMockHandler(ctrl *gomock.Controller) *mock_hook.MockHandler {
+	return mock_hook.NewMockHandler(ctrl)
+}
+
+// NewLifecycleMockHandler creates a new mock_lifecycle.MockLifecycle.
+func NewLifecycleMockHandler(ctrl *gomock.Controller) *mock_lifecycle.MockLifecycle {
+	return mock_lifecycle.NewMockLifecycle(ctrl)
+}
@@ -0,0 +1,57 @@
+package order
+
+import "time"
+
+// Budget computes the deadline of an handler of the order, given the
+// current time, the deadline of the whole order and the number of
+// handlers remaining to shutdown, including the handler.
+type Budget func(now, deadline time.Time, remaining int) (handlerDeadline time.Time)
+
+// BudgetReserve returns a budget reserving a minimum time for each of
+// the handlers remaining after the handler, so an early slow handler
+// cannot starve the following handlers. The handler is still given at
+// least the minimum time given, within the limit of the order deadline.
+func BudgetReserve(minimum time.Duration) Budget {
+	return func(now, deadline time.Time, remaining int) time.Time {
+		handlerDeadline := deadline.Add(-time.Duration(remaining-1) * minimum)
+		floor := now.Add(minimum)
+		if floor.After(deadline) {
+			floor = deadline
+		}
+		if handlerDeadline.Before(floor) {
+			return floor
+		}
+		return handlerDeadline
+	}
+}
+
+// BudgetProportional returns a budget splitting the time remaining
+// until the order deadline equally between the handlers remaining.
+// Time not used by an handler is split between the following handlers.
+func BudgetProportional() Budget {
+	return func(now, deadline time.Time, remaining int) time.Time {
+		return now.Add(deadline.Sub(now) / time.Duration(remaining))
+	}
+}
+
+// BudgetCap returns a budget giving each handler at most
+// the maximum time given.
+func BudgetCap(maximum time.Duration) Budget {
+	return func(now, _ time.Time, _ int) time.Time {
+		return now.Add(maximum)
+	}
+}
+
+// handlerDeadline returns the earliest deadline of the budgets given
+// and of the order deadline.
+func handlerDeadline(budgets []Budget, now, deadline time.Time,
+	remaining int) time.Time {
+	earliest := deadline
+	for _, budget := range budgets {
+		budgetDeadline := budget(now, deadline, remaining)
+		if budgetDeadline.Before(earliest) {
+			earliest = budgetDeadline
+		}
+	}
+	return earliest
+}
@@ -0,0 +1,111 @@
+package order
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Budget(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(0, 0)
+	deadline := now.Add(10 * time.Second)
+
+	testCases := map[string]struct {
+		budget    Budget
+		deadline  time.Time
+		remaining int
+		expected  time.Time
+	}{
+		"reserve for remaining handlers": {
+			budget:    BudgetReserve(2 * time.Second),
+			deadline:  deadline,
+			remaining: 3,
+			expected:  now.Add(6 * time.Second),
+		},
+		"reserve for last handler": {
+			budget:    BudgetReserve(2 * time.Second),
+			deadline:  deadline,
+			remaining: 1,
+			expected:  deadline,
+		},
+		"reserve minimum for handler": {
+			budget:    BudgetReserve(2 * time.Second),
+			deadline:  deadline,
+			remaining: 10,
+			expected:  now.Add(2 * time.Second),
+		},
+		"reserve minimum beyond order deadline": {
+			budget:    BudgetReserve(2 * time.Second),
+			deadline:  now.Add(time.Second),
+			remaining: 2,
+			expected:  now.Add(time.Second),
+		},
+		"proportional": {
+			budget:    BudgetProportional(),
+			deadline:  deadline,
+			remaining: 4,
+			expected:  now.Add(2500 * time.Millisecond),
+		},
+		"proportional for last handler": {
+			budget:    BudgetProportional(),
+			deadline:  deadline,
+			remaining: 1,
+			expected:  deadline,
+		},
+		"cap": {
+			budget:    BudgetCap(time.Second),
+			deadline:  deadline,
+			remaining: 4,
+			expected:  now.Add(time.Second),
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			handlerDeadline := testCase.budget(now, testCase.deadline, testCase.remaining)
+
+			assert.Equal(t, testCase.expected, handlerDeadline)
+		})
+	}
+}
+
+func Test_handlerDeadline(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(0, 0)
+	deadline := now.Add(10 * time.Second)
+
+	testCases := map[string]struct {
+		budgets  []Budget
+		expected time.Time
+	}{
+		"no budget": {
+			expected: deadline,
+		},
+		"earliest budget": {
+			budgets:  []Budget{BudgetProportional(), BudgetCap(time.Second)},
+			expected: now.Add(time.Second),
+		},
+		"budget beyond order deadline": {
+			budgets:  []Budget{BudgetCap(time.Minute)},
+			expected: deadline,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			result := handlerDeadline(testCase.budgets, now, deadline, 2)
+
+			assert.Equal(t, testCase.expected, result)
+		})
+	}
+}
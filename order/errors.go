@@ -0,0 +1,49 @@
+package order
+
+import (
+	"strings"
+
+	"github.com/qdm12/goshutdown/handler"
+	"github.com/qdm12/goshutdown/internal/results"
+)
+
+// ShutdownError is the error returned by Shutdown when one or
+// more handlers of the order failed to shutdown.
+type ShutdownError struct {
+	// Name is the name of the order handler.
+	Name string
+	// Results are the shutdown results of the handlers shut down,
+	// in the order they were shut down. Handlers which were not
+	// shut down because of a critical failure are not included.
+	Results []handler.Result
+	// CriticalFailure is the result of the critical handler which
+	// failed and aborted the order, and is nil if there is none.
+	CriticalFailure *handler.Result
+}
+
+func (e *ShutdownError) Error() string {
+	if e.CriticalFailure != nil {
+		return ErrCriticalTimeout.Error() + ": " +
+			e.CriticalFailure.Name + ": " + e.CriticalFailure.Err.Error()
+	}
+
+	return ErrTimeout.Error() + ": " + strings.Join(results.Messages(e.Failed()), "; ")
+}
+
+// Failed returns the results of the handlers which failed to shutdown.
+func (e *ShutdownError) Failed() (failed []handler.Result) {
+	return results.Failed(e.Results)
+}
+
+// Is returns true if the target is ErrCriticalTimeout for an order
+// aborted by a critical failure, ErrTimeout otherwise, or if the
+// target matches one of the errors of the failed handlers.
+func (e *ShutdownError) Is(target error) bool {
+	return results.Is(e.Results, e.CriticalFailure, target, ErrCriticalTimeout, ErrTimeout)
+}
+
+// As finds the first error of the failed handlers matching the target,
+// and if so sets the target to that error value and returns true.
+func (e *ShutdownError) As(target interface{}) bool {
+	return results.As(e.Results, target)
+}
@@ -0,0 +1,86 @@
+package order
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/qdm12/goshutdown/goroutine"
+	"github.com/qdm12/goshutdown/group"
+	"github.com/qdm12/goshutdown/handler"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ShutdownError(t *testing.T) {
+	t.Parallel()
+
+	groupErr := &group.ShutdownError{
+		Name: "group",
+		Results: []handler.Result{
+			{Name: "A", Err: goroutine.ErrTimeout},
+		},
+	}
+
+	testCases := map[string]struct {
+		err           *ShutdownError
+		message       string
+		isTimeout     bool
+		isCritical    bool
+		failedResults []handler.Result
+	}{
+		"non critical failures": {
+			err: &ShutdownError{
+				Name: "order",
+				Results: []handler.Result{
+					{Name: "group", Duration: time.Second, Err: groupErr},
+					{Name: "B", Duration: time.Second},
+					{Name: "C", Duration: time.Second, Err: goroutine.ErrTimeout},
+				},
+			},
+			message: "ordered shutdown timed out: " +
+				"group: group shutdown timed out: 1 out of 1 goroutines: A: goroutine shutdown timed out; " +
+				"C: goroutine shutdown timed out",
+			isTimeout: true,
+			failedResults: []handler.Result{
+				{Name: "group", Duration: time.Second, Err: groupErr},
+				{Name: "C", Duration: time.Second, Err: goroutine.ErrTimeout},
+			},
+		},
+		"critical failure": {
+			err: &ShutdownError{
+				Name: "order",
+				Results: []handler.Result{
+					{Name: "B"},
+					{Name: "group", Critical: true, Err: groupErr},
+				},
+				CriticalFailure: &handler.Result{Name: "group", Critical: true, Err: groupErr},
+			},
+			message: "critical order handler timed out: " +
+				"group: group shutdown timed out: 1 out of 1 goroutines: A: goroutine shutdown timed out",
+			isCritical: true,
+			failedResults: []handler.Result{
+				{Name: "group", Critical: true, Err: groupErr},
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := testCase.err
+
+			assert.Equal(t, testCase.message, err.Error())
+			assert.Equal(t, testCase.isTimeout, errors.Is(err, ErrTimeout))
+			assert.Equal(t, testCase.isCritical, errors.Is(err, ErrCriticalTimeout))
+			assert.True(t, errors.Is(err, goroutine.ErrTimeout))
+			assert.Equal(t, testCase.failedResults, err.Failed())
+
+			var nestedErr *group.ShutdownError
+			require.True(t, errors.As(err, &nestedErr))
+			assert.Equal(t, groupErr, nestedErr)
+		})
+	}
+}
@@ -4,14 +4,20 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"strings"
+	"time"
 
+	"github.com/qdm12/goshutdown/event"
 	"github.com/qdm12/goshutdown/handler"
+	"github.com/qdm12/goshutdown/hook"
+	"github.com/qdm12/goshutdown/internal/ready"
+	"github.com/qdm12/goshutdown/report"
 )
 
 //go:generate mockgen -destination=mock_$GOPACKAGE/$GOFILE . Handler
 
 // Handler handles an order of shutdown handlers.
+// The handler created by New also implements handler.EarlyExitNotifier,
+// report.Reporter, handler.ReadyStarter, handler.Parent and handler.Timeouter.
 type Handler interface {
 	// Name returns the name set for this order handler.
 	Name() string
@@ -19,13 +25,18 @@ type Handler interface {
 	// before continuing other external shutdown procedures.
 	IsCritical() bool
 	// Shutdown initiates the shutdown process, starting with the start of the order
-	// (group or single goroutine). It returns an error if one or more goroutines
+	// (group or single goroutine). It returns a *ShutdownError if one or more goroutines
 	// did not complete on time, and nil otherwise. You can stop the shutdown process
 	// by canceling its context, but really you should not do that.
+	// The before hooks run first, and the after hooks run last, even if a critical
+	// handler failed to shutdown. Hooks share the timeout of the order, and a hook
+	// failure is reported as a non critical failure in the *ShutdownError.
 	Shutdown(ctx context.Context) (err error)
 	// Append appends one or more handlers to the order. An handler.Handler can be a
 	// group.Handler, a goroutine.Handler or a user defined implementation.
-	// The handlers are appended in a first-in-first-out fashion.
+	// The handlers are shutdown in a first-in-first-out fashion, unless
+	// the order is created with NewReverse or OptionReverse, in which case
+	// they are shutdown in a last-in-first-out fashion.
 	Append(handlers ...handler.Handler)
 }
 
@@ -33,6 +44,8 @@ type orderHandler struct {
 	name     string
 	settings settings
 	handlers []handler.Handler
+	// earlyExitChannels are the channels registered with NotifyEarlyExit.
+	earlyExitChannels []chan<- error
 }
 
 // New creates a new shutdown Handler with the given settings.
@@ -49,6 +62,16 @@ func New(name string, options ...Option) Handler {
 	}
 }
 
+// NewReverse creates a new shutdown Handler shutting down its handlers
+// in the reverse order they were appended. Handlers can therefore be
+// appended in the order they are started, for example a database, a cache
+// and then an HTTP server, and be shutdown in the reverse order.
+// It is equivalent to calling New with OptionReverse.
+func NewReverse(name string, options ...Option) Handler {
+	options = append(append([]Option(nil), options...), OptionReverse())
+	return New(name, options...)
+}
+
 func (h *orderHandler) Name() string {
 	return h.name
 }
@@ -57,42 +80,200 @@ func (h *orderHandler) IsCritical() bool {
 	return h.settings.critical
 }
 
+// Children returns a copy of the handlers of the order,
+// in the order they are shut down.
+func (h *orderHandler) Children() []handler.Handler {
+	children := make([]handler.Handler, len(h.handlers))
+	copy(children, h.shutdownOrder())
+	return children
+}
+
+// Timeout returns the timeout of the order.
+func (h *orderHandler) Timeout() time.Duration {
+	return h.settings.timeout
+}
+
 var (
 	// ErrCriticalTimeout is the error when a critical shutdown timed out in the order.
 	ErrCriticalTimeout = errors.New("critical order handler timed out")
 	// ErrTimeout is the error when one or more shutdown timed out in the order.
 	ErrTimeout = errors.New("ordered shutdown timed out")
+	// ErrReadyTimeout is the error when a handler of the order is not ready
+	// within the ready timeout of the order.
+	ErrReadyTimeout = errors.New("readiness timed out")
 )
 
 func (h *orderHandler) Shutdown(ctx context.Context) (err error) {
+	_, err = h.ShutdownReport(ctx)
+	return err
+}
+
+// ShutdownReport shuts down the order as Shutdown does, and returns
+// the shutdown report of the order and of its nested handlers.
+func (h *orderHandler) ShutdownReport(ctx context.Context) (
+	rep *report.Report, err error) {
+	start := time.Now()
+	rep = &report.Report{
+		Name:     h.name,
+		Kind:     report.KindOrder,
+		Critical: h.settings.critical,
+		Children: make([]*report.Report, 0,
+			len(h.settings.beforeHooks)+len(h.handlers)+len(h.settings.afterHooks)),
+	}
+	ctx, events := event.NewScope(ctx, h.name, h.settings.critical, h.settings.observers...)
+	events.ShutdownStarted()
+	defer func() {
+		rep.Duration = time.Since(start)
+		rep.SetError(err)
+		events.ShutdownFinished(rep, err)
+	}()
+
 	ctx, cancel := context.WithTimeout(ctx, h.settings.timeout)
 	defer cancel()
 
-	var errorMessages []string //nolint:prealloc
-
-	for _, handler := range h.handlers {
-		name := handler.Name()
+	handlers := h.shutdownOrder()
+	results := make([]handler.Result, 0,
+		len(h.settings.beforeHooks)+len(handlers)+len(h.settings.afterHooks))
+	var criticalFailure *handler.Result
+	failed := false
+	handleResult := func(id uint64, childReport *report.Report, childErr error) {
+		events.HandlerFinished(id, childReport, childErr)
+		rep.Children = append(rep.Children, childReport)
+		result := childReport.Result(childErr)
+		results = append(results, result)
+		if childErr == nil {
+			h.settings.onSuccess(result.Name)
+			return
+		}
 
-		err := handler.Shutdown(ctx)
-		if err == nil {
-			h.settings.onSuccess(name)
-			continue
+		failed = true
+		h.settings.onFailure(result.Name, result.Err)
+		if result.Critical {
+			criticalFailure = &result
+			events.CriticalAbort(id, result.Name, result.Err)
 		}
+	}
+	runHook := func(phase hook.Phase, hookToRun hook.Hook) {
+		hookCtx, id := events.HandlerStarted(ctx, hookToRun.Name, false)
+		hookReport, err := hook.Run(hookCtx, phase, hookToRun)
+		handleResult(id, hookReport, err)
+	}
 
-		h.settings.onFailure(name, err)
-		if handler.IsCritical() {
-			return fmt.Errorf("%w: %s: %s", ErrCriticalTimeout, name, err)
+	for _, beforeHook := range h.settings.beforeHooks {
+		runHook(hook.PhaseBefore, beforeHook)
+	}
+
+	for i, child := range handlers {
+		id, childReport, childErr := h.shutdownChild(ctx, events, child, len(handlers)-i)
+		handleResult(id, childReport, childErr)
+		if criticalFailure != nil {
+			for _, skipped := range handlers[i+1:] {
+				name, critical := skipped.Name(), skipped.IsCritical()
+				events.HandlerSkipped(name, critical)
+				rep.Children = append(rep.Children, report.Skipped(name, critical))
+			}
+			break
 		}
-		errorMessages = append(errorMessages, name+": "+err.Error())
 	}
 
-	if len(errorMessages) == 0 {
-		return nil
+	for _, afterHook := range h.settings.afterHooks {
+		runHook(hook.PhaseAfter, afterHook)
 	}
 
-	return fmt.Errorf("%w: %s", ErrTimeout, strings.Join(errorMessages, "; "))
+	if !failed {
+		return rep, nil
+	}
+
+	return rep, &ShutdownError{
+		Name:            h.name,
+		Results:         results,
+		CriticalFailure: criticalFailure,
+	}
+}
+
+// shutdownChild shuts down the child handler given, with a deadline computed
+// by the budgets of the order if any, given the number of handlers remaining
+// to shutdown, including the child. It returns the events ID of the child.
+func (h *orderHandler) shutdownChild(ctx context.Context, events *event.Scope,
+	child handler.Handler, remaining int) (id uint64, childReport *report.Report, err error) {
+	name, critical := child.Name(), child.IsCritical()
+	ctx, id = events.HandlerStarted(ctx, name, critical)
+	orderDeadline, ok := ctx.Deadline()
+	if len(h.settings.budgets) == 0 || !ok {
+		childReport, err = report.Shutdown(ctx, child, name, critical)
+		return id, childReport, err
+	}
+
+	deadline := handlerDeadline(h.settings.budgets, time.Now(), orderDeadline, remaining)
+	h.settings.onDeadline(name, deadline)
+
+	childCtx, cancel := context.WithDeadline(ctx, deadline)
+	defer cancel()
+	childReport, err = report.Shutdown(childCtx, child, name, critical)
+	childReport.Deadline = &deadline
+	return id, childReport, err
+}
+
+// shutdownOrder returns the handlers in the order they must be shutdown.
+func (h *orderHandler) shutdownOrder() (handlers []handler.Handler) {
+	if !h.settings.reverse {
+		return h.handlers
+	}
+
+	handlers = make([]handler.Handler, len(h.handlers))
+	for i, child := range h.handlers {
+		handlers[len(handlers)-1-i] = child
+	}
+	return handlers
+}
+
+// StartAndWaitReady starts the handlers of the order implementing the
+// handler.ReadyStarter interface, such as goroutine.ReadyHandler, and
+// waits for each of them to be ready before starting the next one.
+// Handlers are started in the reverse order of their shutdown, and
+// each handler is given the ready timeout of the order to be ready.
+// It returns an error as soon as a handler fails to be ready, in which
+// case the following handlers are not started.
+func (h *orderHandler) StartAndWaitReady(ctx context.Context) (err error) {
+	handlers := h.shutdownOrder()
+	for i := len(handlers) - 1; i >= 0; i-- {
+		child := handlers[i]
+		starter, ok := child.(handler.ReadyStarter)
+		if !ok {
+			continue
+		}
+
+		err = ready.StartAndWait(ctx, starter, h.settings.readyTimeout, ErrReadyTimeout)
+		if err != nil {
+			return fmt.Errorf("%s: %w", child.Name(), err)
+		}
+	}
+	return nil
 }
 
 func (h *orderHandler) Append(handlers ...handler.Handler) {
+	for _, ch := range h.earlyExitChannels {
+		notifyEarlyExit(handlers, ch)
+	}
 	h.handlers = append(h.handlers, handlers...)
 }
+
+// NotifyEarlyExit registers the channel given to receive a
+// *goroutine.EarlyExitError for each goroutine of the handlers
+// terminating before its shutdown is initiated. This applies
+// recursively to all the handlers implementing the
+// handler.EarlyExitNotifier interface, including the ones added
+// after this call.
+func (h *orderHandler) NotifyEarlyExit(ch chan<- error) {
+	h.earlyExitChannels = append(h.earlyExitChannels, ch)
+	notifyEarlyExit(h.handlers, ch)
+}
+
+func notifyEarlyExit(handlers []handler.Handler, ch chan<- error) {
+	for _, h := range handlers {
+		notifier, ok := h.(handler.EarlyExitNotifier)
+		if ok {
+			notifier.NotifyEarlyExit(ch)
+		}
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/qdm12/goshutdown/goroutine/mock_goroutine"
 	"github.com/qdm12/goshutdown/handler"
 	"github.com/qdm12/goshutdown/handler/mock_handler"
+	"github.com/qdm12/goshutdown/report"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -23,9 +24,11 @@ func Test_New(t *testing.T) {
 	expected := &orderHandler{
 		name: name,
 		settings: settings{
-			timeout:   time.Second,
-			onSuccess: defaultOnSuccess,
-			onFailure: defaultOnFailure,
+			timeout:      time.Second,
+			onSuccess:    defaultOnSuccess,
+			onFailure:    defaultOnFailure,
+			readyTimeout: 10 * time.Second,
+			onDeadline:   defaultOnDeadline,
 		},
 	}
 
@@ -39,6 +42,48 @@ func Test_New(t *testing.T) {
 	assert.Equal(t, expected, impl)
 }
 
+func Test_NewReverse(t *testing.T) {
+	t.Parallel()
+
+	const name = "name"
+
+	expected := &orderHandler{
+		name: name,
+		settings: settings{
+			timeout:      time.Hour,
+			onSuccess:    defaultOnSuccess,
+			onFailure:    defaultOnFailure,
+			reverse:      true,
+			readyTimeout: 10 * time.Second,
+			onDeadline:   defaultOnDeadline,
+		},
+	}
+
+	intf := NewReverse(name, OptionTimeout(time.Hour))
+
+	impl, ok := intf.(*orderHandler)
+	require.True(t, ok)
+
+	assertSettingsEqual(t, &expected.settings, &impl.settings)
+
+	assert.Equal(t, expected, impl)
+}
+
+func Test_NewReverse_options_not_modified(t *testing.T) {
+	t.Parallel()
+
+	options := make([]Option, 1, 2)
+	options[0] = OptionTimeout(time.Hour)
+	spare := options[:2]
+	spare[1] = OptionCritical()
+
+	_ = NewReverse("name", options...)
+
+	var s settings
+	spare[1](&s)
+	assert.Equal(t, settings{critical: true}, s)
+}
+
 func Test_orderHandler_Name(t *testing.T) {
 	t.Parallel()
 	const name = "name"
@@ -63,6 +108,59 @@ func Test_orderHandler_IsCritical(t *testing.T) {
 	assert.Equal(t, critical, c)
 }
 
+func Test_orderHandler_Children(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	a := mock_handler.NewMockHandler(ctrl)
+	b := mock_handler.NewMockHandler(ctrl)
+
+	testCases := map[string]struct {
+		reverse  bool
+		children []handler.Handler
+	}{
+		"in order": {
+			children: []handler.Handler{a, b},
+		},
+		"reverse": {
+			reverse:  true,
+			children: []handler.Handler{b, a},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			h := &orderHandler{
+				settings: settings{reverse: testCase.reverse},
+				handlers: []handler.Handler{a, b},
+			}
+
+			children := h.Children()
+
+			assert.Equal(t, testCase.children, children)
+
+			// Modifying the children does not modify the order
+			children[0] = nil
+			assert.Equal(t, []handler.Handler{a, b}, h.handlers)
+		})
+	}
+}
+
+func Test_orderHandler_Timeout(t *testing.T) {
+	t.Parallel()
+	const timeout = time.Second
+
+	h := &orderHandler{
+		settings: settings{timeout: timeout},
+	}
+	d := h.Timeout()
+
+	assert.Equal(t, timeout, d)
+}
+
 func Test_orderHandler_Shutdown(t *testing.T) {
 	t.Parallel()
 
@@ -126,12 +224,10 @@ func Test_orderHandler_Shutdown(t *testing.T) {
 			criticalFound := false
 			for _, returnValues := range testCase.handlersReturnValues {
 				handler := mock_handler.NewMockHandler(ctrl)
+				handler.EXPECT().Name().Return(returnValues.name)
+				handler.EXPECT().IsCritical().Return(returnValues.critical)
 				if !criticalFound {
-					handler.EXPECT().Name().Return(returnValues.name)
 					handler.EXPECT().Shutdown(gomock.Any()).Return(returnValues.err)
-					if returnValues.err != nil {
-						handler.EXPECT().IsCritical().Return(returnValues.critical)
-					}
 					criticalFound = criticalFound || returnValues.critical
 				}
 				o.Append(handler)
@@ -149,6 +245,69 @@ func Test_orderHandler_Shutdown(t *testing.T) {
 	}
 }
 
+func Test_orderHandler_Shutdown_reverse(t *testing.T) {
+	t.Parallel()
+
+	t.Run("all handlers complete", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+
+		var shutdownNames []string
+		o := NewReverse("order name")
+		for _, name := range []string{"A", "B", "C"} {
+			name := name
+			handler := mock_handler.NewMockHandler(ctrl)
+			handler.EXPECT().Name().Return(name)
+			handler.EXPECT().IsCritical().Return(false)
+			handler.EXPECT().Shutdown(gomock.Any()).DoAndReturn(
+				func(ctx context.Context) error {
+					shutdownNames = append(shutdownNames, name)
+					return nil
+				})
+			o.Append(handler)
+		}
+
+		err := o.Shutdown(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"C", "B", "A"}, shutdownNames)
+	})
+
+	t.Run("critical handler failed", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+
+		o := NewReverse("order name")
+
+		first := mock_handler.NewMockHandler(ctrl)
+		first.EXPECT().Name().Return("first")
+		first.EXPECT().IsCritical().Return(false)
+		o.Append(first) // skipped since appended before the critical handler
+
+		critical := mock_handler.NewMockHandler(ctrl)
+		critical.EXPECT().Name().Return("critical")
+		critical.EXPECT().IsCritical().Return(true)
+		critical.EXPECT().Shutdown(gomock.Any()).Return(goroutine.ErrTimeout)
+		o.Append(critical)
+
+		last := mock_handler.NewMockHandler(ctrl)
+		last.EXPECT().Name().Return("last")
+		last.EXPECT().IsCritical().Return(false)
+		last.EXPECT().Shutdown(gomock.Any()).Return(nil)
+		o.Append(last)
+
+		rep, err := o.(report.Reporter).ShutdownReport(context.Background())
+
+		require.Error(t, err)
+		assert.EqualError(t, err, "critical order handler timed out: critical: goroutine shutdown timed out")
+		require.Len(t, rep.Children, 3)
+		assert.Equal(t, "last", rep.Children[0].Name)
+		assert.Equal(t, "critical", rep.Children[1].Name)
+		assert.Equal(t, "first", rep.Children[2].Name)
+		assert.Equal(t, report.StatusSkipped, rep.Children[2].Status)
+	})
+}
+
 func Test_orderHandler_Append(t *testing.T) {
 	t.Parallel()
 	ctrl := gomock.NewController(t)
@@ -2,10 +2,16 @@ package order
 
 import (
 	"context"
+	"errors"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/qdm12/goshutdown/event"
 	"github.com/qdm12/goshutdown/goroutine"
+	"github.com/qdm12/goshutdown/group"
+	"github.com/qdm12/goshutdown/handler"
+	"github.com/qdm12/goshutdown/report"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -93,3 +99,326 @@ func Test_Handler_GoRoutines_SecondFailsCritical(t *testing.T) {
 	require.Error(t, err)
 	assert.Equal(t, "critical order handler timed out: B: goroutine shutdown timed out: after 1ns", err.Error())
 }
+
+func Test_Handler_Reverse_GoRoutines_LastFailsCritical(t *testing.T) {
+	t.Parallel()
+	order := NewReverse("order", OptionTimeout(2*time.Second))
+
+	handlerA, ctxA, doneA := goroutine.New("A", goroutine.OptionTimeout(time.Nanosecond))
+	go functionB(ctxA, doneA)
+	order.Append(handlerA)
+
+	handlerB, ctxB, doneB := goroutine.New("B", goroutine.OptionTimeout(time.Nanosecond), goroutine.OptionCritical())
+	go functionB(ctxB, doneB)
+	order.Append(handlerB)
+
+	err := order.Shutdown(context.Background())
+	require.Error(t, err)
+	assert.Equal(t, "critical order handler timed out: B: goroutine shutdown timed out: after 1ns", err.Error())
+}
+
+func Test_Handler_GoRoutines_ExitError(t *testing.T) {
+	t.Parallel()
+
+	errFlush := errors.New("failed flushing buffer")
+	var failedName string
+	var failedErr error
+	order := New("order", OptionTimeout(2*time.Second),
+		OptionOnSuccess(func(name string) { t.Errorf("onSuccess should not be called for %q", name) }),
+		OptionOnFailure(func(name string, err error) { failedName, failedErr = name, err }),
+	)
+
+	handlerA, ctxA, doneA := goroutine.NewWithError("A")
+	go func() {
+		<-ctxA.Done()
+		doneA <- errFlush
+	}()
+	order.Append(handlerA)
+
+	err := order.Shutdown(context.Background())
+	require.Error(t, err)
+	assert.ErrorIs(t, failedErr, errFlush)
+	assert.Equal(t, "A", failedName)
+	assert.Equal(t, "ordered shutdown timed out: A: goroutine exited with error: failed flushing buffer", err.Error())
+}
+
+func Test_Handler_NotifyEarlyExit(t *testing.T) {
+	t.Parallel()
+	order := New("order", OptionTimeout(2*time.Second))
+
+	earlyExits := make(chan error)
+	order.(handler.EarlyExitNotifier).NotifyEarlyExit(earlyExits)
+
+	nested := New("nested")
+	order.Append(nested)
+
+	handlerA, ctxA, doneA := goroutine.New("A")
+	go functionA(ctxA, doneA)
+	nested.Append(handlerA)
+
+	handlerB, _, doneB := goroutine.New("B")
+	nested.Append(handlerB)
+	close(doneB)
+
+	err := <-earlyExits
+	assert.Equal(t, "B terminated before shutdown", err.Error())
+
+	err = order.Shutdown(context.Background())
+	require.NoError(t, err)
+}
+
+func Test_Handler_ShutdownReport(t *testing.T) {
+	t.Parallel()
+	order := New("order", OptionTimeout(2*time.Second))
+
+	group := group.New("group", group.OptionTimeout(time.Second))
+	order.Append(group)
+
+	handlerA, ctxA, doneA := goroutine.New("A")
+	go functionA(ctxA, doneA)
+	group.Add(handlerA)
+
+	handlerB, ctxB, doneB := goroutine.New("B", goroutine.OptionTimeout(time.Nanosecond), goroutine.OptionCritical())
+	go functionB(ctxB, doneB)
+	order.Append(handlerB)
+
+	handlerC, ctxC, doneC := goroutine.New("C")
+	go functionA(ctxC, doneC)
+	order.Append(handlerC)
+
+	rep, err := order.(report.Reporter).ShutdownReport(context.Background())
+	require.Error(t, err)
+
+	assert.Equal(t, "order", rep.Name)
+	assert.Equal(t, report.KindOrder, rep.Kind)
+	assert.Equal(t, report.StatusTimeout, rep.Status)
+	assert.Equal(t, err.Error(), rep.Error)
+	require.Len(t, rep.Children, 3)
+
+	groupReport := rep.Children[0]
+	assert.Equal(t, "group", groupReport.Name)
+	assert.Equal(t, report.KindGroup, groupReport.Kind)
+	assert.Equal(t, report.StatusSuccess, groupReport.Status)
+	require.Len(t, groupReport.Children, 1)
+	assert.Equal(t, "A", groupReport.Children[0].Name)
+	assert.Equal(t, report.StatusSuccess, groupReport.Children[0].Status)
+
+	assert.Equal(t, "B", rep.Children[1].Name)
+	assert.True(t, rep.Children[1].Critical)
+	assert.Equal(t, report.StatusTimeout, rep.Children[1].Status)
+
+	assert.Equal(t, "C", rep.Children[2].Name)
+	assert.Equal(t, report.StatusSkipped, rep.Children[2].Status)
+
+	assert.GreaterOrEqual(t, rep.Duration, groupReport.Duration+rep.Children[1].Duration)
+}
+
+func Test_Handler_StartAndWaitReady(t *testing.T) {
+	t.Parallel()
+
+	newReady := func(name string, started chan<- string) goroutine.ReadyHandler {
+		return goroutine.NewReady(name, func(ctx context.Context, ready chan<- struct{}) error {
+			started <- name
+			close(ready)
+			<-ctx.Done()
+			return nil
+		})
+	}
+
+	t.Run("ready in start order", func(t *testing.T) {
+		t.Parallel()
+
+		started := make(chan string, 3)
+		nested := NewReverse("nested")
+		nested.Append(newReady("cache", started))
+
+		order := NewReverse("order")
+		order.Append(newReady("database", started), nested)
+		handlerA, ctxA, doneA := goroutine.New("A")
+		go functionA(ctxA, doneA)
+		order.Append(handlerA)
+		order.Append(newReady("server", started))
+
+		err := order.(handler.ReadyStarter).StartAndWaitReady(context.Background())
+		require.NoError(t, err)
+		close(started)
+
+		var names []string
+		for name := range started {
+			names = append(names, name)
+		}
+		assert.Equal(t, []string{"database", "cache", "server"}, names)
+
+		err = order.Shutdown(context.Background())
+		require.NoError(t, err)
+	})
+
+	t.Run("nested group", func(t *testing.T) {
+		t.Parallel()
+
+		started := make(chan string, 2)
+		workers := group.New("workers")
+		workers.Add(newReady("worker 1", started), newReady("worker 2", started))
+
+		order := New("order")
+		order.Append(workers)
+
+		err := order.(handler.ReadyStarter).StartAndWaitReady(context.Background())
+		require.NoError(t, err)
+		assert.Len(t, started, 2)
+
+		err = order.Shutdown(context.Background())
+		require.NoError(t, err)
+	})
+
+	t.Run("not ready", func(t *testing.T) {
+		t.Parallel()
+
+		started := make(chan string, 1)
+		order := New("order", OptionReadyTimeout(time.Millisecond))
+		order.Append(newReady("server", started)) // shutdown first, so never started
+		order.Append(goroutine.NewReady("database",
+			func(ctx context.Context, ready chan<- struct{}) error {
+				<-ctx.Done()
+				return nil
+			}))
+
+		err := order.(handler.ReadyStarter).StartAndWaitReady(context.Background())
+		require.Error(t, err)
+		assert.ErrorIs(t, err, ErrReadyTimeout)
+		assert.EqualError(t, err, "database: readiness timed out: after 1ms")
+		assert.Empty(t, started)
+
+		err = order.Shutdown(context.Background())
+		require.NoError(t, err)
+	})
+}
+
+func Test_Handler_Hooks(t *testing.T) {
+	t.Parallel()
+
+	errTest := errors.New("test error")
+
+	var calls []string
+	record := func(name string, err error) func(ctx context.Context) error {
+		return func(ctx context.Context) error {
+			calls = append(calls, name)
+			return err
+		}
+	}
+
+	order := New("order", OptionTimeout(time.Second),
+		OptionBeforeHook("flush metrics", record("flush metrics", errTest)),
+		OptionAfterHook("final log", record("final log", nil)))
+
+	handlerA, ctxA, doneA := goroutine.New("A",
+		goroutine.OptionTimeout(time.Nanosecond), goroutine.OptionCritical())
+	go functionB(ctxA, doneA)
+	order.Append(handlerA)
+
+	handlerB, ctxB, doneB := goroutine.New("B")
+	go functionA(ctxB, doneB)
+	order.Append(handlerB)
+
+	rep, err := order.(report.Reporter).ShutdownReport(context.Background())
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrCriticalTimeout)
+	assert.ErrorIs(t, err, errTest)
+	assert.Equal(t, []string{"flush metrics", "final log"}, calls)
+
+	var shutdownErr *ShutdownError
+	require.ErrorAs(t, err, &shutdownErr)
+	require.Len(t, shutdownErr.Results, 3)
+	assert.Equal(t, "flush metrics", shutdownErr.Results[0].Name)
+	assert.Equal(t, "A", shutdownErr.Results[1].Name)
+	assert.Equal(t, "final log", shutdownErr.Results[2].Name)
+	assert.NoError(t, shutdownErr.Results[2].Err)
+
+	require.Len(t, rep.Children, 4)
+	assert.Equal(t, report.KindHook, rep.Children[0].Kind)
+	assert.Equal(t, `before hook "flush metrics" failed: test error`, rep.Children[0].Error)
+	assert.Equal(t, report.StatusSkipped, rep.Children[2].Status)
+	assert.Equal(t, report.KindHook, rep.Children[3].Kind)
+	assert.Equal(t, report.StatusSuccess, rep.Children[3].Status)
+}
+
+func Test_Handler_Budget(t *testing.T) {
+	t.Parallel()
+
+	deadlines := make(map[string]time.Time)
+	order := New("order", OptionTimeout(200*time.Millisecond),
+		OptionBudget(BudgetReserve(100*time.Millisecond)),
+		OptionOnDeadline(func(name string, deadline time.Time) {
+			deadlines[name] = deadline
+		}))
+
+	// slow goroutine which would consume the whole order timeout
+	handlerSlow, ctxSlow, doneSlow := goroutine.New("slow", goroutine.OptionTimeout(time.Hour))
+	go functionB(ctxSlow, doneSlow)
+	order.Append(handlerSlow)
+
+	handlerDatabase, ctxDatabase, doneDatabase := goroutine.New("database",
+		goroutine.OptionTimeout(time.Hour), goroutine.OptionCritical())
+	go functionA(ctxDatabase, doneDatabase)
+	order.Append(handlerDatabase)
+
+	rep, err := order.(report.Reporter).ShutdownReport(context.Background())
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, "ordered shutdown timed out: slow: context deadline exceeded", err.Error())
+
+	require.Len(t, rep.Children, 2)
+	assert.Equal(t, report.StatusTimeout, rep.Children[0].Status)
+	assert.Equal(t, report.StatusSuccess, rep.Children[1].Status)
+	for _, child := range rep.Children {
+		require.NotNil(t, child.Deadline)
+		assert.Equal(t, deadlines[child.Name], *child.Deadline)
+	}
+	assert.True(t, rep.Children[0].Deadline.Before(*rep.Children[1].Deadline))
+}
+
+func Test_Handler_Observer(t *testing.T) {
+	t.Parallel()
+
+	var mutex sync.Mutex
+	var events []string
+	observer := event.ObserverFunc(func(e event.Event) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		events = append(events, string(e.Type)+" "+e.Path)
+	})
+
+	workers := group.New("workers", group.OptionTimeout(time.Second))
+	handlerA, ctxA, doneA := goroutine.New("A")
+	go functionA(ctxA, doneA)
+	workers.Add(handlerA)
+
+	order := New("order", OptionObserver(observer))
+	order.Append(workers)
+	handlerB, ctxB, doneB := goroutine.New("B",
+		goroutine.OptionTimeout(time.Nanosecond), goroutine.OptionCritical())
+	go functionB(ctxB, doneB)
+	order.Append(handlerB)
+	handlerC, ctxC, doneC := goroutine.New("C")
+	go functionA(ctxC, doneC)
+	order.Append(handlerC)
+
+	err := order.Shutdown(context.Background())
+	require.Error(t, err)
+
+	expected := []string{
+		"shutdown_started order",
+		"handler_started order/workers",
+		"shutdown_started order/workers",
+		"handler_started order/workers/A",
+		"handler_succeeded order/workers/A",
+		"shutdown_finished order/workers",
+		"handler_succeeded order/workers",
+		"handler_started order/B",
+		"handler_failed order/B",
+		"critical_abort order/B",
+		"handler_skipped order/C",
+		"shutdown_finished order",
+	}
+	assert.Equal(t, expected, events)
+}
@@ -1,6 +1,12 @@
 package order
 
-import "time"
+import (
+	"context"
+	"time"
+
+	"github.com/qdm12/goshutdown/event"
+	"github.com/qdm12/goshutdown/hook"
+)
 
 type Option func(s *settings)
 
@@ -32,3 +38,67 @@ func OptionOnFailure(fn func(orderName string, err error)) Option {
 		s.onFailure = fn
 	}
 }
+
+// OptionReverse sets the order to shutdown its handlers in the reverse
+// order they were appended, in a last-in-first-out fashion. This is
+// useful to append handlers in the order their goroutines are started,
+// such that they are shutdown in the reverse order.
+func OptionReverse() Option {
+	return func(s *settings) {
+		s.reverse = true
+	}
+}
+
+// OptionReadyTimeout sets a timeout for each handler of the order to be ready
+// in StartAndWaitReady. Note the timeout defaults to ten seconds, and can
+// be set to 0 to disable it.
+func OptionReadyTimeout(timeout time.Duration) Option {
+	return func(s *settings) {
+		s.readyTimeout = timeout
+	}
+}
+
+// OptionBudget adds one or more budgets computing the deadline of each
+// handler of the order, such as BudgetReserve, BudgetProportional and
+// BudgetCap. Each handler is given the earliest deadline of all the budgets
+// and of the order. By default, each handler shares the order deadline.
+func OptionBudget(budgets ...Budget) Option {
+	return func(s *settings) {
+		s.budgets = append(s.budgets, budgets...)
+	}
+}
+
+// OptionOnDeadline sets a function to execute before shutting down an
+// handler of the order, with the deadline computed by the budgets of the order.
+func OptionOnDeadline(fn func(name string, deadline time.Time)) Option {
+	return func(s *settings) {
+		s.onDeadline = fn
+	}
+}
+
+// OptionObserver adds an observer of the shutdown events of the order and of
+// its nested order, group and graph handlers. Nested handlers also deliver
+// their events to the observers of their parent handlers.
+func OptionObserver(observer event.Observer) Option {
+	return func(s *settings) {
+		s.observers = append(s.observers, observer)
+	}
+}
+
+// OptionBeforeHook adds a hook to run before shutting down the handlers
+// of the order. Hooks run in the order they are added, share the timeout
+// of the order, and their failures do not stop the shutdown.
+func OptionBeforeHook(name string, fn func(ctx context.Context) error) Option {
+	return func(s *settings) {
+		s.beforeHooks = append(s.beforeHooks, hook.Hook{Name: name, Fn: fn})
+	}
+}
+
+// OptionAfterHook adds a hook to run after shutting down the handlers
+// of the order, even if a critical handler failed to shutdown. Hooks run
+// in the order they are added, and share the timeout of the order.
+func OptionAfterHook(name string, fn func(ctx context.Context) error) Option {
+	return func(s *settings) {
+		s.afterHooks = append(s.afterHooks, hook.Hook{Name: name, Fn: fn})
+	}
+}
@@ -1,6 +1,11 @@
 package order
 
-import "time"
+import (
+	"time"
+
+	"github.com/qdm12/goshutdown/event"
+	"github.com/qdm12/goshutdown/hook"
+)
 
 // settings defines configuration settings for the shutdown Order.
 type settings struct {
@@ -16,15 +21,44 @@ type settings struct {
 	// OnSuccess defines a function to execute when an handler in the order
 	// does not terminate on time. It is disabled if it is left unset.
 	onFailure func(name string, err error)
+	// reverse can be set to true to shutdown the handlers of the order
+	// in the reverse order they were appended, in a last-in-first-out fashion.
+	reverse bool
+	// readyTimeout is the timeout for each handler of the order to be ready
+	// in StartAndWaitReady. It defaults to 10s if left unset, and no timeout
+	// is applied if it is set to 0.
+	readyTimeout time.Duration
+	// beforeHooks are the hooks to run, in order, before shutting
+	// down the handlers of the order.
+	beforeHooks []hook.Hook
+	// afterHooks are the hooks to run, in order, after shutting down
+	// the handlers of the order, even if a critical handler failed.
+	afterHooks []hook.Hook
+	// budgets are the budgets computing the deadline of each handler.
+	// The earliest deadline of the budgets and of the order is used.
+	// Each handler shares the order deadline if left unset.
+	budgets []Budget
+	// onDeadline defines a function to execute before shutting down an
+	// handler in the order, with the deadline computed by the budgets.
+	// It is disabled if it is left unset, and is not called if no
+	// budget is set.
+	onDeadline func(name string, deadline time.Time)
+	// observers are the observers of the shutdown events of the order
+	// and of its nested handlers, in addition to the observers of the
+	// parent handlers. It is disabled if it is left unset.
+	observers []event.Observer
 }
 
 func newSettings() settings {
 	return settings{
-		timeout:   time.Second,
-		onSuccess: defaultOnSuccess,
-		onFailure: defaultOnFailure,
+		timeout:      time.Second,
+		onSuccess:    defaultOnSuccess,
+		onFailure:    defaultOnFailure,
+		readyTimeout: 10 * time.Second,
+		onDeadline:   defaultOnDeadline,
 	}
 }
 
-func defaultOnSuccess(name string)            {}
-func defaultOnFailure(name string, err error) {}
+func defaultOnSuccess(name string)                      {}
+func defaultOnFailure(name string, err error)           {}
+func defaultOnDeadline(name string, deadline time.Time) {}
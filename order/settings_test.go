@@ -21,12 +21,15 @@ func Test_newSettings(t *testing.T) {
 	assert.NotPanics(t, func() {
 		s.onSuccess("group")
 		s.onFailure("group", errDummy)
+		s.onDeadline("group", time.Time{})
 	})
 
 	expected := settings{
-		timeout:   time.Second,
-		onSuccess: defaultOnSuccess,
-		onFailure: defaultOnFailure,
+		timeout:      time.Second,
+		onSuccess:    defaultOnSuccess,
+		onFailure:    defaultOnFailure,
+		readyTimeout: 10 * time.Second,
+		onDeadline:   defaultOnDeadline,
 	}
 
 	assertSettingsEqual(t, &expected, &s)
@@ -42,5 +45,8 @@ func assertSettingsEqual(t *testing.T, a, b *settings) {
 	assert.Equal(t, reflect.ValueOf(a.onSuccess), reflect.ValueOf(b.onSuccess))
 	a.onSuccess, b.onSuccess = nil, nil
 
+	assert.Equal(t, reflect.ValueOf(a.onDeadline), reflect.ValueOf(b.onDeadline))
+	a.onDeadline, b.onDeadline = nil, nil
+
 	assert.Equal(t, a, b)
 }
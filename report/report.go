@@ -0,0 +1,143 @@
+// Package report defines a tree shaped shutdown report mirroring
+// the nested structure of shutdown handlers.
+package report
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/qdm12/goshutdown/goroutine"
+	"github.com/qdm12/goshutdown/handler"
+)
+
+// Reporter is implemented by handlers able to produce a shutdown report.
+type Reporter interface {
+	// ShutdownReport shuts down the handler as Shutdown would do,
+	// and returns the shutdown report of the handler and its children.
+	ShutdownReport(ctx context.Context) (report *Report, err error)
+}
+
+// Kind is the kind of handler a report is for.
+type Kind string
+
+const (
+	// KindOrder is the kind for an order handler.
+	KindOrder Kind = "order"
+	// KindGroup is the kind for a group handler.
+	KindGroup Kind = "group"
+	// KindGraph is the kind for a graph handler.
+	KindGraph Kind = "graph"
+	// KindHook is the kind for a hook run before or after
+	// the shutdown of an handler.
+	KindHook Kind = "hook"
+	// KindHandler is the kind for any other handler,
+	// such as a goroutine handler.
+	KindHandler Kind = "handler"
+)
+
+// Status is the shutdown status of a handler.
+type Status string
+
+const (
+	// StatusSuccess is the status for an handler shut down successfully.
+	StatusSuccess Status = "success"
+	// StatusFailure is the status for an handler which failed to shutdown.
+	StatusFailure Status = "failure"
+	// StatusTimeout is the status for an handler which timed out.
+	StatusTimeout Status = "timeout"
+	// StatusSkipped is the status for an handler which was not shut down,
+	// because of a critical failure of a preceding handler.
+	StatusSkipped Status = "skipped"
+)
+
+// Report is the shutdown report of an handler and of its children.
+type Report struct {
+	// Name is the name of the handler.
+	Name string `json:"name"`
+	// Kind is the kind of the handler.
+	Kind Kind `json:"kind"`
+	// Critical is true if the handler is critical.
+	Critical bool `json:"critical"`
+	// Status is the shutdown status of the handler.
+	Status Status `json:"status"`
+	// Error is the shutdown error message, and is empty if
+	// the shutdown succeeded.
+	Error string `json:"error,omitempty"`
+	// Duration is the time taken to shutdown the handler.
+	Duration time.Duration `json:"duration"`
+	// Deadline is the effective deadline given to the handler by the
+	// budget of its parent order, and is nil if no budget is set.
+	Deadline *time.Time `json:"deadline,omitempty"`
+	// Children are the reports of the children handlers, for
+	// order and group handlers. Children of an order are in the
+	// order of the shutdown, and children of a group are in the
+	// order of their completion.
+	Children []*Report `json:"children,omitempty"`
+}
+
+// SetError sets the status and error fields of the report
+// using the shutdown error given.
+func (r *Report) SetError(err error) {
+	switch {
+	case err == nil:
+		r.Status = StatusSuccess
+		r.Error = ""
+		return
+	case errors.Is(err, goroutine.ErrTimeout),
+		errors.Is(err, context.DeadlineExceeded):
+		r.Status = StatusTimeout
+	default:
+		r.Status = StatusFailure
+	}
+	r.Error = err.Error()
+}
+
+// Result returns the handler.Result for the report and the shutdown
+// error given.
+func (r *Report) Result(err error) handler.Result {
+	return handler.Result{
+		Name:     r.Name,
+		Critical: r.Critical,
+		Duration: r.Duration,
+		Err:      err,
+		Hook:     r.Kind == KindHook,
+	}
+}
+
+// Shutdown shuts down the handler given and returns its report and its
+// shutdown error. It uses the ShutdownReport method of the handler if it
+// implements Reporter, and otherwise creates a report of kind KindHandler.
+// The name and critical fields of the report are set to the ones given,
+// to avoid calling the Name and IsCritical methods of the handler again.
+func Shutdown(ctx context.Context, h handler.Handler, name string, critical bool) (
+	report *Report, err error) {
+	reporter, ok := h.(Reporter)
+	if ok {
+		report, err = reporter.ShutdownReport(ctx)
+		report.Name = name
+		report.Critical = critical
+		return report, err
+	}
+
+	start := time.Now()
+	err = h.Shutdown(ctx)
+	report = &Report{
+		Name:     name,
+		Kind:     KindHandler,
+		Critical: critical,
+		Duration: time.Since(start),
+	}
+	report.SetError(err)
+	return report, err
+}
+
+// Skipped returns a report for a skipped handler.
+func Skipped(name string, critical bool) *Report {
+	return &Report{
+		Name:     name,
+		Kind:     KindHandler,
+		Critical: critical,
+		Status:   StatusSkipped,
+	}
+}
@@ -0,0 +1,232 @@
+package report
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/qdm12/goshutdown/goroutine"
+	"github.com/qdm12/goshutdown/handler"
+	"github.com/qdm12/goshutdown/handler/mock_handler"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Report_SetError(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		err    error
+		status Status
+		errStr string
+	}{
+		"success": {
+			status: StatusSuccess,
+		},
+		"failure": {
+			err:    errors.New("test"),
+			status: StatusFailure,
+			errStr: "test",
+		},
+		"goroutine timeout": {
+			err:    goroutine.ErrTimeout,
+			status: StatusTimeout,
+			errStr: "goroutine shutdown timed out",
+		},
+		"context deadline exceeded": {
+			err:    context.DeadlineExceeded,
+			status: StatusTimeout,
+			errStr: "context deadline exceeded",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			report := &Report{Error: "previous error"}
+			report.SetError(testCase.err)
+
+			assert.Equal(t, testCase.status, report.Status)
+			assert.Equal(t, testCase.errStr, report.Error)
+		})
+	}
+}
+
+type reporterHandler struct {
+	*mock_handler.MockHandler
+	report *Report
+}
+
+func (r *reporterHandler) ShutdownReport(ctx context.Context) (report *Report, err error) {
+	return r.report, nil
+}
+
+func Test_Shutdown(t *testing.T) {
+	t.Parallel()
+
+	t.Run("handler", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+
+		h := mock_handler.NewMockHandler(ctrl)
+		h.EXPECT().Shutdown(gomock.Any()).Return(goroutine.ErrTimeout)
+
+		report, err := Shutdown(context.Background(), h, "name", true)
+
+		assert.ErrorIs(t, err, goroutine.ErrTimeout)
+		assert.GreaterOrEqual(t, report.Duration, time.Duration(0))
+		report.Duration = 0
+		expected := &Report{
+			Name:     "name",
+			Kind:     KindHandler,
+			Critical: true,
+			Status:   StatusTimeout,
+			Error:    "goroutine shutdown timed out",
+		}
+		assert.Equal(t, expected, report)
+	})
+
+	t.Run("reporter", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+
+		h := &reporterHandler{
+			MockHandler: mock_handler.NewMockHandler(ctrl),
+			report: &Report{
+				Kind:   KindGroup,
+				Status: StatusSuccess,
+			},
+		}
+
+		report, err := Shutdown(context.Background(), h, "name", true)
+
+		require.NoError(t, err)
+		expected := &Report{
+			Name:     "name",
+			Kind:     KindGroup,
+			Critical: true,
+			Status:   StatusSuccess,
+		}
+		assert.Equal(t, expected, report)
+	})
+}
+
+func Test_Skipped(t *testing.T) {
+	t.Parallel()
+
+	report := Skipped("name", true)
+
+	expected := &Report{
+		Name:     "name",
+		Kind:     KindHandler,
+		Critical: true,
+		Status:   StatusSkipped,
+	}
+	assert.Equal(t, expected, report)
+}
+
+func Test_Report_Result(t *testing.T) {
+	t.Parallel()
+
+	errTest := errors.New("test error")
+
+	testCases := map[string]struct {
+		report *Report
+		err    error
+		result handler.Result
+	}{
+		"handler": {
+			report: &Report{Name: "A", Kind: KindHandler, Critical: true, Duration: time.Second},
+			err:    errTest,
+			result: handler.Result{Name: "A", Critical: true, Duration: time.Second, Err: errTest},
+		},
+		"hook": {
+			report: &Report{Name: "flush", Kind: KindHook, Duration: time.Second},
+			result: handler.Result{Name: "flush", Duration: time.Second, Hook: true},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			result := testCase.report.Result(testCase.err)
+
+			assert.Equal(t, testCase.result, result)
+		})
+	}
+}
+
+func newTestReport() *Report {
+	return &Report{
+		Name:     "order",
+		Kind:     KindOrder,
+		Status:   StatusFailure,
+		Error:    "critical order handler timed out: B: goroutine shutdown timed out",
+		Duration: 2 * time.Second,
+		Children: []*Report{
+			{
+				Name:     "group",
+				Kind:     KindGroup,
+				Status:   StatusSuccess,
+				Duration: time.Second,
+				Children: []*Report{
+					{Name: "A", Kind: KindHandler, Status: StatusSuccess, Duration: time.Second},
+				},
+			},
+			{
+				Name:     "B",
+				Kind:     KindHandler,
+				Critical: true,
+				Status:   StatusTimeout,
+				Error:    "goroutine shutdown timed out",
+				Duration: time.Second,
+			},
+			{Name: "C", Kind: KindHandler, Status: StatusSkipped},
+		},
+	}
+}
+
+func Test_Report_String(t *testing.T) {
+	t.Parallel()
+
+	s := newTestReport().String()
+
+	const expected = `NAME     KIND     CRITICAL  STATUS   DURATION  ERROR
+order    order    false     failure  2s        critical order handler timed out: B: goroutine shutdown timed out
+  group  group    false     success  1s        
+    A    handler  false     success  1s        
+  B      handler  true      timeout  1s        goroutine shutdown timed out
+  C      handler  false     skipped  0s        
+`
+	assert.Equal(t, expected, s)
+}
+
+func Test_Report_JSON(t *testing.T) {
+	t.Parallel()
+
+	report := newTestReport()
+
+	b, err := json.Marshal(report)
+	require.NoError(t, err)
+
+	const expected = `{"name":"order","kind":"order","critical":false,"status":"failure",` +
+		`"error":"critical order handler timed out: B: goroutine shutdown timed out","duration":2000000000,` +
+		`"children":[{"name":"group","kind":"group","critical":false,"status":"success","duration":1000000000,` +
+		`"children":[{"name":"A","kind":"handler","critical":false,"status":"success","duration":1000000000}]},` +
+		`{"name":"B","kind":"handler","critical":true,"status":"timeout",` +
+		`"error":"goroutine shutdown timed out","duration":1000000000},` +
+		`{"name":"C","kind":"handler","critical":false,"status":"skipped","duration":0}]}`
+	assert.JSONEq(t, expected, string(b))
+
+	var decoded Report
+	err = json.Unmarshal(b, &decoded)
+	require.NoError(t, err)
+	assert.Equal(t, report, &decoded)
+}
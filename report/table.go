@@ -0,0 +1,56 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// WriteTable writes the report as a human readable table to the writer given.
+// Children handlers are indented below their parent handler.
+func (r *Report) WriteTable(w io.Writer) (err error) {
+	const minWidth, tabWidth, padding = 0, 8, 2
+	tabWriter := tabwriter.NewWriter(w, minWidth, tabWidth, padding, ' ', 0)
+
+	_, err = fmt.Fprintln(tabWriter, "NAME\tKIND\tCRITICAL\tSTATUS\tDURATION\tERROR")
+	if err != nil {
+		return fmt.Errorf("writing header: %w", err)
+	}
+
+	err = r.writeRows(tabWriter, 0)
+	if err != nil {
+		return err
+	}
+
+	err = tabWriter.Flush()
+	if err != nil {
+		return fmt.Errorf("flushing table: %w", err)
+	}
+	return nil
+}
+
+func (r *Report) writeRows(w io.Writer, depth int) (err error) {
+	name := strings.Repeat("  ", depth) + r.Name
+	_, err = fmt.Fprintf(w, "%s\t%s\t%t\t%s\t%s\t%s\n",
+		name, r.Kind, r.Critical, r.Status, r.Duration.Round(time.Microsecond), r.Error)
+	if err != nil {
+		return fmt.Errorf("writing row for %s: %w", r.Name, err)
+	}
+
+	for _, child := range r.Children {
+		err = child.writeRows(w, depth+1)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// String returns the report as a human readable table.
+func (r *Report) String() string {
+	builder := new(strings.Builder)
+	_ = r.WriteTable(builder)
+	return builder.String()
+}
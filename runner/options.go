@@ -0,0 +1,68 @@
+package runner
+
+import (
+	"io"
+	"os"
+)
+
+type Option func(s *settings)
+
+// OptionSignals sets the OS signals triggering the shutdown.
+// Note the signals default to SIGINT and SIGTERM.
+func OptionSignals(signals ...os.Signal) Option {
+	return func(s *settings) {
+		s.signals = signals
+	}
+}
+
+// OptionSignalChannel sets the channel to receive OS signals from,
+// instead of registering a new channel with signal.Notify.
+// This is mostly useful for testing purposes.
+func OptionSignalChannel(signalCh <-chan os.Signal) Option {
+	return func(s *settings) {
+		s.signalCh = signalCh
+	}
+}
+
+// OptionFatal sets a channel of fatal errors, where any error
+// received triggers the shutdown.
+func OptionFatal(fatal <-chan error) Option {
+	return func(s *settings) {
+		s.fatal = fatal
+	}
+}
+
+// OptionForceExit enables forcing the exit when an OS signal is received
+// during the shutdown. In this case, the shutdown context is canceled,
+// a dump of all the goroutines stacks is written to the writer given
+// and Run returns ExitCodeForced without waiting for the shutdown to complete.
+// If the writer given is nil, it defaults to os.Stderr.
+func OptionForceExit(stackWriter io.Writer) Option {
+	return func(s *settings) {
+		s.forceExit = true
+		if stackWriter != nil {
+			s.stackWriter = stackWriter
+		}
+	}
+}
+
+// OptionOnSignal sets a function to execute when an OS signal is received.
+func OptionOnSignal(fn func(signal os.Signal)) Option {
+	return func(s *settings) {
+		s.onSignal = fn
+	}
+}
+
+// OptionOnFatal sets a function to execute when a fatal error is received.
+func OptionOnFatal(fn func(err error)) Option {
+	return func(s *settings) {
+		s.onFatal = fn
+	}
+}
+
+// OptionOnShutdownFailure sets a function to execute when the shutdown fails.
+func OptionOnShutdownFailure(fn func(err error)) Option {
+	return func(s *settings) {
+		s.onShutdownFailure = fn
+	}
+}
@@ -0,0 +1,101 @@
+// Package runner runs the lifecycle of a program main function,
+// waiting for an OS signal or a fatal error before shutting down
+// the root handler given.
+package runner
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"runtime"
+
+	"github.com/qdm12/goshutdown/handler"
+)
+
+const (
+	// ExitCodeSuccess is the exit code when the shutdown was triggered
+	// by an OS signal and completed successfully.
+	ExitCodeSuccess = 0
+	// ExitCodeFatal is the exit code when the shutdown was triggered
+	// by a fatal error.
+	ExitCodeFatal = 1
+	// ExitCodeShutdownFailure is the exit code when the shutdown was
+	// triggered by an OS signal but failed to complete.
+	ExitCodeShutdownFailure = 2
+	// ExitCodeForced is the exit code when the shutdown was aborted
+	// by an OS signal received during the shutdown. This only happens
+	// if the OptionForceExit option is set.
+	ExitCodeForced = 3
+)
+
+// Run blocks until an OS signal or a fatal error is received,
+// and then shuts down the root handler given. It returns an exit code
+// to use with os.Exit, which is one of ExitCodeSuccess, ExitCodeFatal,
+// ExitCodeShutdownFailure or ExitCodeForced.
+func Run(root handler.Handler, options ...Option) (exitCode int) {
+	settings := newSettings()
+	for _, option := range options {
+		option(&settings)
+	}
+
+	signalCh := settings.signalCh
+	if signalCh == nil {
+		notifyCh := make(chan os.Signal, 1)
+		signal.Notify(notifyCh, settings.signals...)
+		defer signal.Stop(notifyCh)
+		signalCh = notifyCh
+	}
+
+	select {
+	case sig := <-signalCh:
+		settings.onSignal(sig)
+		exitCode = ExitCodeSuccess
+	case err := <-settings.fatal:
+		settings.onFatal(err)
+		exitCode = ExitCodeFatal
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	shutdownErrCh := make(chan error, 1)
+	go func() {
+		shutdownErrCh <- root.Shutdown(ctx)
+	}()
+
+	var forceSignalCh <-chan os.Signal
+	if settings.forceExit {
+		forceSignalCh = signalCh
+	}
+
+	var err error
+	select {
+	case err = <-shutdownErrCh:
+	case sig := <-forceSignalCh:
+		settings.onSignal(sig)
+		_, _ = settings.stackWriter.Write(goroutinesStack())
+		cancel()
+		return ExitCodeForced
+	}
+
+	if err != nil {
+		settings.onShutdownFailure(err)
+		if exitCode == ExitCodeSuccess {
+			exitCode = ExitCodeShutdownFailure
+		}
+	}
+
+	return exitCode
+}
+
+// goroutinesStack returns the stack traces of all the goroutines.
+func goroutinesStack() (stack []byte) {
+	const initialSize = 64 * 1024
+	stack = make([]byte, initialSize)
+	for {
+		n := runtime.Stack(stack, true)
+		if n < len(stack) {
+			return stack[:n]
+		}
+		stack = make([]byte, 2*len(stack)) //nolint:gomnd
+	}
+}
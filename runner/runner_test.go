@@ -0,0 +1,117 @@
+package runner
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"syscall"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/qdm12/goshutdown/handler/mock_handler"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Run(t *testing.T) {
+	t.Parallel()
+
+	errTest := errors.New("test error")
+
+	testCases := map[string]struct {
+		signal      os.Signal
+		fatalErr    error
+		shutdownErr error
+		exitCode    int
+	}{
+		"signal and shutdown success": {
+			signal:   syscall.SIGTERM,
+			exitCode: ExitCodeSuccess,
+		},
+		"signal and shutdown failure": {
+			signal:      syscall.SIGINT,
+			shutdownErr: errTest,
+			exitCode:    ExitCodeShutdownFailure,
+		},
+		"fatal error and shutdown success": {
+			fatalErr: errTest,
+			exitCode: ExitCodeFatal,
+		},
+		"fatal error and shutdown failure": {
+			fatalErr:    errTest,
+			shutdownErr: errTest,
+			exitCode:    ExitCodeFatal,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			root := mock_handler.NewMockHandler(ctrl)
+			root.EXPECT().Shutdown(gomock.Any()).Return(testCase.shutdownErr)
+
+			signalCh := make(chan os.Signal, 1)
+			fatal := make(chan error, 1)
+			if testCase.signal != nil {
+				signalCh <- testCase.signal
+			} else {
+				fatal <- testCase.fatalErr
+			}
+
+			var receivedSignal os.Signal
+			var fatalErr, shutdownErr error
+
+			exitCode := Run(root,
+				OptionSignalChannel(signalCh),
+				OptionFatal(fatal),
+				OptionOnSignal(func(signal os.Signal) { receivedSignal = signal }),
+				OptionOnFatal(func(err error) { fatalErr = err }),
+				OptionOnShutdownFailure(func(err error) { shutdownErr = err }),
+			)
+
+			assert.Equal(t, testCase.exitCode, exitCode)
+			assert.Equal(t, testCase.signal, receivedSignal)
+			assert.Equal(t, testCase.fatalErr, fatalErr)
+			assert.Equal(t, testCase.shutdownErr, shutdownErr)
+		})
+	}
+}
+
+func Test_Run_forceExit(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	shutdownCtxCh := make(chan context.Context)
+	root := mock_handler.NewMockHandler(ctrl)
+	root.EXPECT().Shutdown(gomock.Any()).DoAndReturn(
+		func(ctx context.Context) error {
+			shutdownCtxCh <- ctx
+			<-ctx.Done()
+			return ctx.Err()
+		})
+
+	signalCh := make(chan os.Signal)
+	stackWriter := bytes.NewBuffer(nil)
+
+	exitCodeCh := make(chan int)
+	go func() {
+		exitCodeCh <- Run(root,
+			OptionSignalChannel(signalCh),
+			OptionForceExit(stackWriter),
+		)
+	}()
+
+	signalCh <- syscall.SIGINT
+	shutdownCtx := <-shutdownCtxCh
+	signalCh <- syscall.SIGINT
+
+	exitCode := <-exitCodeCh
+	assert.Equal(t, ExitCodeForced, exitCode)
+	require.Error(t, shutdownCtx.Err())
+	assert.Contains(t, stackWriter.String(), "goroutine ")
+	assert.Contains(t, stackWriter.String(), "Test_Run_forceExit")
+}
@@ -0,0 +1,51 @@
+package runner
+
+import (
+	"io"
+	"os"
+	"syscall"
+)
+
+// settings defines configuration settings for the runner.
+type settings struct {
+	// signals are the OS signals triggering the shutdown.
+	// They default to SIGINT and SIGTERM if left unset.
+	signals []os.Signal
+	// signalCh is the channel to receive OS signals from.
+	// If left unset, a channel is created and registered
+	// with signal.Notify for the signals field.
+	signalCh <-chan os.Signal
+	// fatal is a channel of fatal errors triggering the shutdown.
+	// It is disabled if it is left unset.
+	fatal <-chan error
+	// forceExit can be set to true so that an OS signal received
+	// during the shutdown aborts the shutdown, writes a dump of all
+	// the goroutines stacks to stackWriter and returns ExitCodeForced.
+	forceExit bool
+	// stackWriter is the writer to write the goroutines stack dump to
+	// when the exit is forced. It defaults to os.Stderr if left unset.
+	stackWriter io.Writer
+	// onSignal defines a function to execute when an OS signal
+	// is received. It is disabled if it is left unset.
+	onSignal func(signal os.Signal)
+	// onFatal defines a function to execute when a fatal error
+	// is received. It is disabled if it is left unset.
+	onFatal func(err error)
+	// onShutdownFailure defines a function to execute when the shutdown
+	// of the root handler fails. It is disabled if it is left unset.
+	onShutdownFailure func(err error)
+}
+
+func newSettings() settings {
+	return settings{
+		signals:           []os.Signal{syscall.SIGINT, syscall.SIGTERM},
+		stackWriter:       os.Stderr,
+		onSignal:          defaultOnSignal,
+		onFatal:           defaultOnFatal,
+		onShutdownFailure: defaultOnShutdownFailure,
+	}
+}
+
+func defaultOnSignal(signal os.Signal)   {}
+func defaultOnFatal(err error)           {}
+func defaultOnShutdownFailure(err error) {}
@@ -0,0 +1,51 @@
+package runner
+
+import (
+	"errors"
+	"os"
+	"reflect"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_newSettings(t *testing.T) {
+	t.Parallel()
+
+	errDummy := errors.New("dummy")
+
+	s := newSettings()
+
+	assert.NotPanics(t, func() {
+		s.onSignal(syscall.SIGINT)
+		s.onFatal(errDummy)
+		s.onShutdownFailure(errDummy)
+	})
+
+	expected := settings{
+		signals:           []os.Signal{syscall.SIGINT, syscall.SIGTERM},
+		stackWriter:       os.Stderr,
+		onSignal:          defaultOnSignal,
+		onFatal:           defaultOnFatal,
+		onShutdownFailure: defaultOnShutdownFailure,
+	}
+
+	assertSettingsEqual(t, &expected, &s)
+}
+
+// asserts the settings a and b are equal and clear the problematic fields
+// that cannot be asserted without reflect such as functions.
+func assertSettingsEqual(t *testing.T, a, b *settings) {
+	t.Helper()
+	assert.Equal(t, reflect.ValueOf(a.onSignal), reflect.ValueOf(b.onSignal))
+	a.onSignal, b.onSignal = nil, nil
+
+	assert.Equal(t, reflect.ValueOf(a.onFatal), reflect.ValueOf(b.onFatal))
+	a.onFatal, b.onFatal = nil, nil
+
+	assert.Equal(t, reflect.ValueOf(a.onShutdownFailure), reflect.ValueOf(b.onShutdownFailure))
+	a.onShutdownFailure, b.onShutdownFailure = nil, nil
+
+	assert.Equal(t, a, b)
+}
@@ -28,6 +28,14 @@ func NewGoRoutineHandler(name string, options ...goroutine.Option) (
 	return goroutine.New(name, options...)
 }
 
+// NewGoRoutineHandlerWithError creates a new handler for a goroutine using the
+// name and options given. It returns the handler as well as the context
+// and error done channel to use in the actual Goroutine.
+func NewGoRoutineHandlerWithError(name string, options ...goroutine.Option) (
+	h goroutine.Handler, ctx context.Context, done chan<- error) {
+	return goroutine.NewWithError(name, options...)
+}
+
 // NewGroupHandler creates a new group handler using the name and options given.
 func NewGroupHandler(name string, options ...group.Option) group.Handler {
 	return group.New(name, options...)
@@ -0,0 +1,358 @@
+// Package supervisor defines a shutdown handler restarting
+// its children goroutines if they exit before the shutdown.
+package supervisor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/qdm12/goshutdown/goroutine"
+	"github.com/qdm12/goshutdown/group"
+	"github.com/qdm12/goshutdown/handler"
+)
+
+//go:generate mockgen -destination=mock_$GOPACKAGE/$GOFILE . Handler
+
+// Handler handles a supervisor of goroutines.
+// The handler created by New also implements handler.Parent
+// and handler.Timeouter.
+type Handler interface {
+	// Name returns the name set for this supervisor handler.
+	Name() string
+	// IsCritical returns true if the supervisor handler is critical
+	// and must be terminated before continuing other external shutdown procedures.
+	IsCritical() bool
+	// Shutdown stops restarting children and shuts down all the children
+	// in parallel. It returns an error wrapping ErrMaxRestarts for each child
+	// which exceeded its maximum number of restarts, and wrapping a
+	// *group.ShutdownError if one or more children did not terminate on time.
+	Shutdown(ctx context.Context) (err error)
+	// Add adds a child goroutine to the supervisor. The function fn is launched
+	// in its own goroutine using goroutine.Go with the options given, and is
+	// restarted if it exits before the shutdown. Children must be added before
+	// calling Start.
+	Add(name string, fn func(ctx context.Context) error, options ...goroutine.Option)
+	// Start launches all the children goroutines and starts supervising them.
+	Start()
+	// NotifyEarlyExit registers the channel given to receive a
+	// *goroutine.EarlyExitError when a child exits before the shutdown
+	// and exceeded its maximum number of restarts.
+	NotifyEarlyExit(ch chan<- error)
+}
+
+// Strategy is the restart strategy for the children of a supervisor.
+type Strategy uint8
+
+const (
+	// OneForOne only restarts the child which exited.
+	OneForOne Strategy = iota
+	// OneForAll shuts down all the other children and restarts
+	// all the children when one child exits.
+	OneForAll
+)
+
+type child struct {
+	name    string
+	fn      func(ctx context.Context) error
+	options []goroutine.Option
+	handler goroutine.Handler
+	// generation is incremented each time the child is started,
+	// to ignore exit notifications from previous goroutines.
+	generation int
+	restarts   int
+	// gaveUp is set to true when the child exceeded
+	// its maximum number of restarts.
+	gaveUp bool
+}
+
+type exit struct {
+	index      int
+	generation int
+}
+
+type supervisor struct {
+	name     string
+	settings settings
+	children []*child
+	// handlersMutex protects the handler field of the children,
+	// which is read by Children while the children are supervised.
+	handlersMutex sync.Mutex
+	exited        chan exit
+	// earlyExitChannels are the channels registered with NotifyEarlyExit,
+	// protected by earlyExitMutex since they can be registered while the
+	// children are supervised.
+	earlyExitChannels []chan<- error
+	earlyExitMutex    sync.Mutex
+	stop              context.CancelFunc
+	stopped           chan struct{}
+	// errs are set by the supervising goroutine for each child
+	// which exceeded its maximum number of restarts.
+	errs []error
+}
+
+// New creates a new supervisor handler with the given name and options.
+func New(name string, options ...Option) Handler {
+	settings := newSettings()
+	for _, option := range options {
+		option(&settings)
+	}
+
+	return &supervisor{
+		name:     name,
+		settings: settings,
+		exited:   make(chan exit),
+	}
+}
+
+func (s *supervisor) Name() string {
+	return s.name
+}
+
+func (s *supervisor) IsCritical() bool {
+	return s.settings.critical
+}
+
+// Children returns the goroutine handlers of the running children, in
+// the order the children were added. Children not started yet or waiting
+// to be restarted are not included.
+func (s *supervisor) Children() []handler.Handler {
+	s.handlersMutex.Lock()
+	defer s.handlersMutex.Unlock()
+	children := make([]handler.Handler, 0, len(s.children))
+	for _, child := range s.children {
+		if child.handler != nil {
+			children = append(children, child.handler)
+		}
+	}
+	return children
+}
+
+// Timeout returns the timeout for terminating all the children,
+// where 0 indicates no timeout.
+func (s *supervisor) Timeout() time.Duration {
+	return s.settings.timeout
+}
+
+func (s *supervisor) Add(name string, fn func(ctx context.Context) error,
+	options ...goroutine.Option) {
+	s.children = append(s.children, &child{
+		name:    name,
+		fn:      fn,
+		options: options,
+	})
+}
+
+func (s *supervisor) Start() {
+	for i := range s.children {
+		s.startChild(i)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.stop = cancel
+	s.stopped = make(chan struct{})
+	go s.supervise(ctx)
+}
+
+var (
+	// ErrMaxRestarts is the error when a child exceeded its maximum number of restarts.
+	ErrMaxRestarts = errors.New("maximum number of restarts reached")
+)
+
+func (s *supervisor) Shutdown(ctx context.Context) (err error) {
+	if s.stop != nil {
+		s.stop()
+		select {
+		case <-s.stopped:
+		case <-ctx.Done():
+			return ctx.Err() //nolint:wrapcheck
+		}
+	}
+
+	group := group.New(s.name, group.OptionTimeout(s.settings.timeout))
+	for _, child := range s.children {
+		if child.handler != nil {
+			group.Add(child.handler)
+		}
+	}
+
+	errs := append([]error(nil), s.errs...)
+	err = group.Shutdown(ctx)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	return joinErrors(errs)
+}
+
+// joinErrors returns nil if there is no error, and otherwise an error
+// wrapping all the errors given, with their messages separated by "; ".
+func joinErrors(errs []error) (err error) {
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errs[0]
+	default:
+		return &joinedError{errs: errs}
+	}
+}
+
+type joinedError struct {
+	errs []error
+}
+
+func (e *joinedError) Error() string {
+	messages := make([]string, len(e.errs))
+	for i, err := range e.errs {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+func (e *joinedError) Is(target error) bool {
+	for _, err := range e.errs {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *joinedError) As(target interface{}) bool {
+	for _, err := range e.errs {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *supervisor) NotifyEarlyExit(ch chan<- error) {
+	s.earlyExitMutex.Lock()
+	defer s.earlyExitMutex.Unlock()
+	s.earlyExitChannels = append(s.earlyExitChannels, ch)
+}
+
+func (s *supervisor) notifyEarlyExit(ctx context.Context, childName string, exitErr error) {
+	s.earlyExitMutex.Lock()
+	channels := make([]chan<- error, len(s.earlyExitChannels))
+	copy(channels, s.earlyExitChannels)
+	s.earlyExitMutex.Unlock()
+
+	for _, ch := range channels {
+		go func(ch chan<- error) {
+			err := &goroutine.EarlyExitError{
+				Name: childName,
+				Err:  exitErr,
+			}
+			select {
+			case ch <- err:
+			case <-ctx.Done():
+			}
+		}(ch)
+	}
+}
+
+func (s *supervisor) startChild(index int) {
+	child := s.children[index]
+	child.generation++
+	generation := child.generation
+	fn := child.fn
+
+	wrapped := func(ctx context.Context) error {
+		defer func() {
+			select {
+			case s.exited <- exit{index: index, generation: generation}:
+			case <-ctx.Done(): // exit expected from a shutdown
+			}
+		}()
+		return fn(ctx)
+	}
+
+	s.setHandler(child, goroutine.Go(child.name, wrapped, child.options...))
+}
+
+// setHandler sets the goroutine handler of the child given.
+func (s *supervisor) setHandler(child *child, h goroutine.Handler) {
+	s.handlersMutex.Lock()
+	defer s.handlersMutex.Unlock()
+	child.handler = h
+}
+
+func (s *supervisor) supervise(ctx context.Context) {
+	defer close(s.stopped)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case exit := <-s.exited:
+			child := s.children[exit.index]
+			if exit.generation != child.generation {
+				continue // old goroutine exiting
+			}
+
+			// The goroutine exited so its shutdown only collects its exit error.
+			exitErr := child.handler.Shutdown(ctx)
+			s.setHandler(child, nil)
+
+			if s.settings.maxRestarts >= 0 && child.restarts >= s.settings.maxRestarts {
+				child.gaveUp = true
+				s.errs = append(s.errs, fmt.Errorf("%w: %s: after %d restarts: %v",
+					ErrMaxRestarts, child.name, child.restarts, exitErr))
+				s.settings.onMaxRestarts(child.name, exitErr)
+				s.notifyEarlyExit(ctx, child.name, exitErr)
+				continue
+			}
+
+			child.restarts++
+			s.settings.onRestart(child.name, exitErr)
+
+			timer := time.NewTimer(s.backoff(child.restarts))
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+
+			if s.settings.strategy == OneForAll {
+				s.restartAll(ctx, exit.index)
+				continue
+			}
+			s.startChild(exit.index)
+		}
+	}
+}
+
+// restartAll shuts down all the children except the one at the
+// index given, which is already stopped, and starts all the children.
+func (s *supervisor) restartAll(ctx context.Context, stoppedIndex int) {
+	for i, child := range s.children {
+		if i == stoppedIndex || child.handler == nil {
+			continue
+		}
+		_ = child.handler.Shutdown(ctx)
+		s.setHandler(child, nil)
+	}
+
+	for i, child := range s.children {
+		if !child.gaveUp {
+			s.startChild(i)
+		}
+	}
+}
+
+// backoff returns the waiting time before the restart number given.
+func (s *supervisor) backoff(restart int) (wait time.Duration) {
+	wait = s.settings.backoffInitial
+	for i := 1; i < restart && wait < s.settings.backoffMax; i++ {
+		wait *= 2
+	}
+	if wait > s.settings.backoffMax {
+		wait = s.settings.backoffMax
+	}
+	return wait
+}
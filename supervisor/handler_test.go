@@ -0,0 +1,269 @@
+package supervisor
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/qdm12/goshutdown/goroutine"
+	"github.com/qdm12/goshutdown/group"
+	"github.com/qdm12/goshutdown/handler"
+	"github.com/qdm12/goshutdown/order"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_supervisor_Name(t *testing.T) {
+	t.Parallel()
+	const name = "name"
+
+	s := &supervisor{
+		name: name,
+	}
+	n := s.Name()
+
+	assert.Equal(t, name, n)
+}
+
+func Test_supervisor_IsCritical(t *testing.T) {
+	t.Parallel()
+	const critical = true
+
+	s := &supervisor{
+		settings: settings{critical: critical},
+	}
+	c := s.IsCritical()
+
+	assert.Equal(t, critical, c)
+}
+
+func Test_supervisor_backoff(t *testing.T) {
+	t.Parallel()
+
+	s := &supervisor{
+		settings: settings{
+			backoffInitial: time.Second,
+			backoffMax:     5 * time.Second,
+		},
+	}
+
+	assert.Equal(t, time.Second, s.backoff(1))
+	assert.Equal(t, 2*time.Second, s.backoff(2))
+	assert.Equal(t, 4*time.Second, s.backoff(3))
+	assert.Equal(t, 5*time.Second, s.backoff(4))
+	assert.Equal(t, 5*time.Second, s.backoff(100))
+}
+
+func Test_supervisor_Shutdown_not_started(t *testing.T) {
+	t.Parallel()
+
+	s := New("supervisor")
+	s.Add("child", func(ctx context.Context) error { return nil })
+
+	err := s.Shutdown(context.Background())
+	assert.NoError(t, err)
+}
+
+func Test_supervisor_Shutdown_timeout(t *testing.T) {
+	t.Parallel()
+
+	s := New("supervisor", OptionTimeout(10*time.Millisecond))
+	stuck := make(chan struct{})
+	t.Cleanup(func() { close(stuck) })
+	s.Add("stuck", func(ctx context.Context) error {
+		<-stuck
+		return nil
+	}, goroutine.OptionTimeout(time.Hour))
+
+	s.Start()
+
+	err := s.Shutdown(context.Background())
+	require.Error(t, err)
+	var shutdownErr *group.ShutdownError
+	require.ErrorAs(t, err, &shutdownErr)
+	assert.Equal(t, []string{"stuck"}, shutdownErr.StillRunning())
+	assert.ErrorIs(t, err, group.ErrTimeout)
+	assert.Equal(t, "group shutdown timed out: 1 out of 1 goroutines: "+
+		"stuck: still running: after group timeout of 10ms (still running: stuck)", err.Error())
+}
+
+// restartRecorder records the restarts of children.
+type restartRecorder struct {
+	mutex    sync.Mutex
+	restarts map[string]int
+	errs     []error
+}
+
+func (r *restartRecorder) onRestart(childName string, err error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.restarts == nil {
+		r.restarts = make(map[string]int)
+	}
+	r.restarts[childName]++
+	r.errs = append(r.errs, err)
+}
+
+func (r *restartRecorder) get(childName string) int {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.restarts[childName]
+}
+
+func Test_supervisor_OneForOne(t *testing.T) {
+	t.Parallel()
+
+	errCrash := errors.New("crash")
+	recorder := new(restartRecorder)
+	s := New("supervisor",
+		OptionBackoff(time.Millisecond, time.Millisecond),
+		OptionOnRestart(recorder.onRestart),
+	)
+
+	crashes := make(chan struct{}, 2)
+	crashes <- struct{}{}
+	crashes <- struct{}{}
+	close(crashes)
+	s.Add("crashing", func(ctx context.Context) error {
+		if _, ok := <-crashes; ok {
+			return errCrash
+		}
+		<-ctx.Done()
+		return nil
+	}, goroutine.OptionTimeout(time.Second))
+
+	stableStarts := make(chan struct{}, 10)
+	s.Add("stable", func(ctx context.Context) error {
+		stableStarts <- struct{}{}
+		<-ctx.Done()
+		return nil
+	}, goroutine.OptionTimeout(time.Second))
+
+	s.Start()
+
+	require.Eventually(t, func() bool {
+		return recorder.get("crashing") == 2
+	}, time.Second, time.Millisecond)
+
+	err := s.Shutdown(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, 0, recorder.get("stable"))
+	assert.Len(t, stableStarts, 1)
+	for _, err := range recorder.errs {
+		assert.ErrorIs(t, err, errCrash)
+	}
+}
+
+func Test_supervisor_OneForAll(t *testing.T) {
+	t.Parallel()
+
+	recorder := new(restartRecorder)
+	s := New("supervisor",
+		OptionStrategy(OneForAll),
+		OptionBackoff(time.Millisecond, time.Millisecond),
+		OptionOnRestart(recorder.onRestart),
+	)
+
+	crashes := make(chan struct{}, 1)
+	crashes <- struct{}{}
+	close(crashes)
+	s.Add("crashing", func(ctx context.Context) error {
+		if _, ok := <-crashes; ok {
+			panic("crash")
+		}
+		<-ctx.Done()
+		return nil
+	}, goroutine.OptionTimeout(time.Second))
+
+	stableStarts := make(chan struct{}, 10)
+	s.Add("stable", func(ctx context.Context) error {
+		stableStarts <- struct{}{}
+		<-ctx.Done()
+		return nil
+	}, goroutine.OptionTimeout(time.Second))
+
+	s.Start()
+
+	require.Eventually(t, func() bool {
+		return len(stableStarts) == 2
+	}, time.Second, time.Millisecond)
+
+	err := s.Shutdown(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, recorder.get("crashing"))
+	require.Len(t, recorder.errs, 1)
+	var panicErr *goroutine.PanicError
+	assert.ErrorAs(t, recorder.errs[0], &panicErr)
+}
+
+func Test_supervisor_MaxRestarts(t *testing.T) {
+	t.Parallel()
+
+	recorder := new(restartRecorder)
+	gaveUp := make(chan string)
+	s := New("supervisor",
+		OptionMaxRestarts(2),
+		OptionBackoff(time.Millisecond, time.Millisecond),
+		OptionOnRestart(recorder.onRestart),
+		OptionOnMaxRestarts(func(childName string, err error) { gaveUp <- childName }),
+	)
+
+	s.Add("crashing", func(ctx context.Context) error {
+		return nil
+	})
+
+	earlyExits := make(chan error)
+	s.NotifyEarlyExit(earlyExits)
+
+	s.Start()
+
+	childName := <-gaveUp
+	assert.Equal(t, "crashing", childName)
+	assert.Equal(t, 2, recorder.get("crashing"))
+
+	earlyExitErr := <-earlyExits
+	var earlyExit *goroutine.EarlyExitError
+	require.ErrorAs(t, earlyExitErr, &earlyExit)
+	assert.Equal(t, "crashing", earlyExit.Name)
+
+	err := s.Shutdown(context.Background())
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrMaxRestarts)
+	assert.Equal(t, "maximum number of restarts reached: crashing: after 2 restarts: <nil>", err.Error())
+}
+
+func Test_supervisor_MaxRestarts_all_children(t *testing.T) {
+	t.Parallel()
+
+	gaveUp := make(chan string)
+	s := New("supervisor",
+		OptionMaxRestarts(0),
+		OptionOnMaxRestarts(func(childName string, err error) { gaveUp <- childName }),
+	)
+	s.Add("A", func(ctx context.Context) error { return nil })
+	s.Add("B", func(ctx context.Context) error { return nil })
+
+	s.Start()
+
+	// The early exit channel is registered after the start,
+	// as done by an order handler appending the supervisor.
+	o := order.New("order")
+	o.Append(s)
+	earlyExits := make(chan error)
+	notifier, ok := o.(handler.EarlyExitNotifier)
+	require.True(t, ok)
+	notifier.NotifyEarlyExit(earlyExits)
+
+	names := []string{<-gaveUp, <-gaveUp}
+	assert.ElementsMatch(t, []string{"A", "B"}, names)
+
+	err := s.Shutdown(context.Background())
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrMaxRestarts)
+	assert.Contains(t, err.Error(), "maximum number of restarts reached: A: after 0 restarts: <nil>")
+	assert.Contains(t, err.Error(), "maximum number of restarts reached: B: after 0 restarts: <nil>")
+}
@@ -0,0 +1,64 @@
+package supervisor
+
+import "time"
+
+type Option func(s *settings)
+
+// OptionTimeout sets a timeout for the shutdown of all the children.
+// Note the timeout defaults to one second.
+func OptionTimeout(timeout time.Duration) Option {
+	return func(s *settings) {
+		s.timeout = timeout
+	}
+}
+
+// OptionCritical marks the shutdown operation as critical.
+func OptionCritical() Option {
+	return func(s *settings) {
+		s.critical = true
+	}
+}
+
+// OptionStrategy sets the restart strategy for the children.
+// Note the strategy defaults to OneForOne.
+func OptionStrategy(strategy Strategy) Option {
+	return func(s *settings) {
+		s.strategy = strategy
+	}
+}
+
+// OptionMaxRestarts sets the maximum number of restarts for each child.
+// A negative value means the children are restarted indefinitely.
+// Note the maximum number of restarts defaults to 5.
+func OptionMaxRestarts(maxRestarts int) Option {
+	return func(s *settings) {
+		s.maxRestarts = maxRestarts
+	}
+}
+
+// OptionBackoff sets the initial and maximum waiting times before
+// restarting a child. The waiting time is doubled on each restart
+// of the child, up to the maximum waiting time given.
+// Note they default to 100ms and 10s respectively.
+func OptionBackoff(initial, maximum time.Duration) Option {
+	return func(s *settings) {
+		s.backoffInitial = initial
+		s.backoffMax = maximum
+	}
+}
+
+// OptionOnRestart sets a function to execute when a child exited
+// before the shutdown and is about to be restarted.
+func OptionOnRestart(fn func(childName string, err error)) Option {
+	return func(s *settings) {
+		s.onRestart = fn
+	}
+}
+
+// OptionOnMaxRestarts sets a function to execute when a child exited
+// before the shutdown and exceeded its maximum number of restarts.
+func OptionOnMaxRestarts(fn func(childName string, err error)) Option {
+	return func(s *settings) {
+		s.onMaxRestarts = fn
+	}
+}
@@ -0,0 +1,54 @@
+package supervisor
+
+import "time"
+
+// settings defines configuration settings for the supervisor.
+type settings struct {
+	// timeout is the timeout for terminating all the children
+	// of the supervisor. It defaults to 1s if left unset.
+	timeout time.Duration
+	// critical can be set to true to indicate the shutdown process should exit if
+	// the children of the supervisor cannot be terminated.
+	critical bool
+	// strategy is the restart strategy to use when a child exits
+	// before the shutdown. It defaults to OneForOne if left unset.
+	strategy Strategy
+	// maxRestarts is the maximum number of restarts for each child.
+	// It defaults to 5 if left unset, and a negative value means
+	// the children are restarted indefinitely.
+	maxRestarts int
+	// backoffInitial is the initial waiting time before restarting a child.
+	// It is doubled for each restart of the child, up to backoffMax.
+	// It defaults to 100ms if left unset.
+	backoffInitial time.Duration
+	// backoffMax is the maximum waiting time before restarting a child.
+	// It defaults to 10s if left unset.
+	backoffMax time.Duration
+	// onRestart defines a function to execute when a child exited before
+	// the shutdown and is about to be restarted. It is disabled if it is left unset.
+	onRestart func(childName string, err error)
+	// onMaxRestarts defines a function to execute when a child exited before
+	// the shutdown and exceeded its maximum number of restarts, so it is
+	// no longer restarted. It is disabled if it is left unset.
+	onMaxRestarts func(childName string, err error)
+}
+
+func newSettings() settings {
+	const (
+		defaultMaxRestarts    = 5
+		defaultBackoffInitial = 100 * time.Millisecond
+		defaultBackoffMax     = 10 * time.Second
+	)
+	return settings{
+		timeout:        time.Second,
+		strategy:       OneForOne,
+		maxRestarts:    defaultMaxRestarts,
+		backoffInitial: defaultBackoffInitial,
+		backoffMax:     defaultBackoffMax,
+		onRestart:      defaultOnRestart,
+		onMaxRestarts:  defaultOnMaxRestarts,
+	}
+}
+
+func defaultOnRestart(childName string, err error)     {}
+func defaultOnMaxRestarts(childName string, err error) {}
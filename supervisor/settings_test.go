@@ -0,0 +1,48 @@
+package supervisor
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_newSettings(t *testing.T) {
+	t.Parallel()
+
+	errDummy := errors.New("dummy")
+
+	s := newSettings()
+
+	assert.NotPanics(t, func() {
+		s.onRestart("child", errDummy)
+		s.onMaxRestarts("child", errDummy)
+	})
+
+	expected := settings{
+		timeout:        time.Second,
+		strategy:       OneForOne,
+		maxRestarts:    5,
+		backoffInitial: 100 * time.Millisecond,
+		backoffMax:     10 * time.Second,
+		onRestart:      defaultOnRestart,
+		onMaxRestarts:  defaultOnMaxRestarts,
+	}
+
+	assertSettingsEqual(t, &expected, &s)
+}
+
+// asserts the settings a and b are equal and clear the problematic fields
+// that cannot be asserted without reflect such as functions.
+func assertSettingsEqual(t *testing.T, a, b *settings) {
+	t.Helper()
+	assert.Equal(t, reflect.ValueOf(a.onRestart), reflect.ValueOf(b.onRestart))
+	a.onRestart, b.onRestart = nil, nil
+
+	assert.Equal(t, reflect.ValueOf(a.onMaxRestarts), reflect.ValueOf(b.onMaxRestarts))
+	a.onMaxRestarts, b.onMaxRestarts = nil, nil
+
+	assert.Equal(t, a, b)
+}
@@ -0,0 +1,111 @@
+package tracing
+
+import (
+	"context"
+	"sync"
+
+	"github.com/qdm12/goshutdown/event"
+	"github.com/qdm12/goshutdown/graph"
+	"github.com/qdm12/goshutdown/group"
+	"github.com/qdm12/goshutdown/order"
+	"github.com/qdm12/goshutdown/report"
+)
+
+// Observer is an event.Observer producing a span for the shutdown of each
+// handler of the tree, including goroutine handlers, with the span of a
+// handler being a child of the span of its parent handler. It should be set
+// on the root handler only, since the events of nested handlers are
+// propagated to the observers of their parents.
+type Observer struct {
+	tracer Tracer
+	mutex  sync.Mutex
+	// spans maps event IDs to the spans started and not ended yet,
+	// so handlers with the same path each have their own span.
+	spans map[uint64]startedSpan
+}
+
+type startedSpan struct {
+	ctx  context.Context //nolint:containedctx
+	span Span
+}
+
+// New creates a tracing observer starting spans with the tracer given.
+func New(tracer Tracer) *Observer {
+	return &Observer{
+		tracer: tracer,
+		spans:  make(map[uint64]startedSpan),
+	}
+}
+
+// Observe starts or ends spans depending on the event given. Shutdown
+// started and finished events are only used for the root handler, since
+// the shutdowns of nested handlers are traced with the handler events of
+// their parent. Skipped handlers have a span with a zero duration.
+func (o *Observer) Observe(e event.Event) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	isRoot := e.ParentID == 0
+
+	switch e.Type {
+	case event.TypeShutdownStarted:
+		if isRoot {
+			o.start(e)
+		}
+	case event.TypeHandlerStarted:
+		o.start(e)
+	case event.TypeShutdownFinished:
+		if isRoot {
+			o.end(e)
+		}
+	case event.TypeHandlerSucceeded, event.TypeHandlerFailed:
+		o.end(e)
+	case event.TypeHandlerSkipped:
+		o.start(e)
+		e.Status = report.StatusSkipped
+		o.end(e)
+	case event.TypeCriticalAbort:
+	}
+}
+
+func (o *Observer) start(e event.Event) {
+	parentCtx := context.Background()
+	parent, ok := o.spans[e.ParentID]
+	if ok {
+		parentCtx = parent.ctx
+	}
+
+	ctx, span := o.tracer.Start(parentCtx, e.Name, e.Time,
+		Attribute{Key: KeyPath, Value: e.Path},
+		Attribute{Key: KeyCritical, Value: e.Critical},
+	)
+	o.spans[e.ID] = startedSpan{ctx: ctx, span: span}
+}
+
+func (o *Observer) end(e event.Event) {
+	started, ok := o.spans[e.ID]
+	if !ok {
+		return
+	}
+	delete(o.spans, e.ID)
+
+	started.span.End(e.Time, e.Err, Attribute{Key: KeyStatus, Value: string(e.Status)})
+}
+
+// OrderOption returns an order option tracing the shutdown
+// of the order and of its nested handlers.
+func (o *Observer) OrderOption() order.Option {
+	return order.OptionObserver(o)
+}
+
+// GroupOption returns a group option tracing the shutdown
+// of the group and of its nested handlers.
+func (o *Observer) GroupOption() group.Option {
+	return group.OptionObserver(o)
+}
+
+// GraphOption returns a graph option tracing the shutdown
+// of the graph and of its nested handlers.
+func (o *Observer) GraphOption() graph.Option {
+	return graph.OptionObserver(o)
+}
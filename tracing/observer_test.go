@@ -0,0 +1,189 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/qdm12/goshutdown/event"
+	"github.com/qdm12/goshutdown/goroutine"
+	"github.com/qdm12/goshutdown/group"
+	"github.com/qdm12/goshutdown/report"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type contextKey struct{}
+
+// testTracer records the spans started, with spans
+// contained in the context given to Start being parents.
+type testTracer struct {
+	spans []*testSpan
+}
+
+type testSpan struct {
+	name       string
+	parent     *testSpan
+	start      time.Time
+	end        time.Time
+	err        error
+	attributes []Attribute
+}
+
+func (t *testTracer) Start(ctx context.Context, name string, start time.Time,
+	attributes ...Attribute) (spanCtx context.Context, span Span) {
+	parent, _ := ctx.Value(contextKey{}).(*testSpan)
+	s := &testSpan{
+		name:       name,
+		parent:     parent,
+		start:      start,
+		attributes: attributes,
+	}
+	t.spans = append(t.spans, s)
+	return context.WithValue(ctx, contextKey{}, s), s
+}
+
+func (s *testSpan) End(end time.Time, err error, attributes ...Attribute) {
+	s.end = end
+	s.err = err
+	s.attributes = append(s.attributes, attributes...)
+}
+
+func Test_New(t *testing.T) {
+	t.Parallel()
+
+	tracer := new(testTracer)
+
+	observer := New(tracer)
+
+	assert.Equal(t, &Observer{
+		tracer: tracer,
+		spans:  map[uint64]startedSpan{},
+	}, observer)
+}
+
+func Test_Observer_Observe(t *testing.T) {
+	t.Parallel()
+
+	errTest := errors.New("test error")
+	base := time.Unix(0, 0)
+	at := func(milliseconds int) time.Time {
+		return base.Add(time.Duration(milliseconds) * time.Millisecond)
+	}
+
+	tracer := new(testTracer)
+	observer := New(tracer)
+
+	events := []event.Event{
+		{Type: event.TypeShutdownStarted, Time: at(0), Path: "root", Name: "root", ID: 1},
+		{Type: event.TypeHandlerStarted, Time: at(1), Path: "root/group", Name: "group",
+			ID: 2, ParentID: 1},
+		// Nested scope events are ignored since the parent
+		// emits the handler events for the same shutdown.
+		{Type: event.TypeShutdownStarted, Time: at(1), Path: "root/group", Name: "group",
+			ID: 2, ParentID: 1},
+		{Type: event.TypeHandlerStarted, Time: at(2), Path: "root/group/A", Name: "A",
+			ID: 3, ParentID: 2, Critical: true},
+		{Type: event.TypeHandlerFailed, Time: at(5), Path: "root/group/A", Name: "A",
+			ID: 3, ParentID: 2, Critical: true, Status: report.StatusFailure, Err: errTest},
+		{Type: event.TypeShutdownFinished, Time: at(6), Path: "root/group", Name: "group",
+			ID: 2, ParentID: 1, Status: report.StatusFailure, Err: errTest},
+		{Type: event.TypeHandlerFailed, Time: at(6), Path: "root/group", Name: "group",
+			ID: 2, ParentID: 1, Status: report.StatusFailure, Err: errTest},
+		{Type: event.TypeCriticalAbort, Time: at(6), Path: "root/group", Name: "group",
+			ID: 2, ParentID: 1},
+		{Type: event.TypeHandlerSkipped, Time: at(7), Path: "root/B", Name: "B",
+			ID: 4, ParentID: 1},
+		{Type: event.TypeShutdownFinished, Time: at(8), Path: "root", Name: "root", ID: 1,
+			Status: report.StatusFailure, Err: errTest},
+	}
+	for _, e := range events {
+		observer.Observe(e)
+	}
+
+	root := &testSpan{
+		name:  "root",
+		start: at(0),
+		end:   at(8),
+		err:   errTest,
+		attributes: []Attribute{
+			{Key: KeyPath, Value: "root"},
+			{Key: KeyCritical, Value: false},
+			{Key: KeyStatus, Value: "failure"},
+		},
+	}
+	group := &testSpan{
+		name:   "group",
+		parent: root,
+		start:  at(1),
+		end:    at(6),
+		err:    errTest,
+		attributes: []Attribute{
+			{Key: KeyPath, Value: "root/group"},
+			{Key: KeyCritical, Value: false},
+			{Key: KeyStatus, Value: "failure"},
+		},
+	}
+	expectedSpans := []*testSpan{
+		root,
+		group,
+		{
+			name:   "A",
+			parent: group,
+			start:  at(2),
+			end:    at(5),
+			err:    errTest,
+			attributes: []Attribute{
+				{Key: KeyPath, Value: "root/group/A"},
+				{Key: KeyCritical, Value: true},
+				{Key: KeyStatus, Value: "failure"},
+			},
+		},
+		{
+			name:   "B",
+			parent: root,
+			start:  at(7),
+			end:    at(7),
+			attributes: []Attribute{
+				{Key: KeyPath, Value: "root/B"},
+				{Key: KeyCritical, Value: false},
+				{Key: KeyStatus, Value: "skipped"},
+			},
+		},
+	}
+	assert.Equal(t, expectedSpans, tracer.spans)
+	assert.Empty(t, observer.spans)
+}
+
+func Test_Observer_sameNameSiblings(t *testing.T) {
+	t.Parallel()
+
+	tracer := new(testTracer)
+	observer := New(tracer)
+
+	workers := group.New("workers", observer.GroupOption())
+	succeeding, ctx, done := goroutine.New("worker")
+	go func() {
+		<-ctx.Done()
+		close(done)
+	}()
+	failing, _, _ := goroutine.New("worker", goroutine.OptionTimeout(time.Millisecond))
+	workers.Add(succeeding, failing)
+
+	err := workers.Shutdown(context.Background())
+	require.Error(t, err)
+
+	require.Len(t, tracer.spans, 3)
+	root := tracer.spans[0]
+	assert.Equal(t, "workers", root.name)
+	assert.ErrorIs(t, root.err, group.ErrTimeout)
+	// spans are started in the order the handlers were added
+	assert.Equal(t, "worker", tracer.spans[1].name)
+	assert.Equal(t, root, tracer.spans[1].parent)
+	assert.NoError(t, tracer.spans[1].err)
+	assert.Equal(t, "worker", tracer.spans[2].name)
+	assert.Equal(t, root, tracer.spans[2].parent)
+	assert.ErrorIs(t, tracer.spans[2].err, goroutine.ErrTimeout)
+	assert.Empty(t, observer.spans)
+}
@@ -0,0 +1,181 @@
+package otlp
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+
+	"github.com/qdm12/goshutdown/tracing"
+)
+
+// File is an exporter appending the spans exported to a file in the
+// OTLP JSON format, with one export request per line, as written by the
+// file exporter of the OpenTelemetry collector. The file can be loaded
+// by the OTLP JSON file receiver of the collector.
+type File struct {
+	path        string
+	serviceName string
+	mutex       sync.Mutex
+}
+
+// NewFile creates an exporter appending spans to the file at the path
+// given, with the service name given as resource attribute.
+func NewFile(path, serviceName string) *File {
+	return &File{
+		path:        path,
+		serviceName: serviceName,
+	}
+}
+
+// Export appends the spans given to the file, creating it if needed.
+func (f *File) Export(spans []SpanData) (err error) {
+	data, err := json.Marshal(newExportRequest(f.serviceName, spans))
+	if err != nil {
+		return fmt.Errorf("encoding spans: %w", err)
+	}
+	data = append(data, '\n')
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	const perm = 0o644
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, perm)
+	if err != nil {
+		return fmt.Errorf("opening file: %w", err)
+	}
+
+	_, err = file.Write(data)
+	if err != nil {
+		_ = file.Close()
+		return fmt.Errorf("writing spans: %w", err)
+	}
+
+	err = file.Close()
+	if err != nil {
+		return fmt.Errorf("closing file: %w", err)
+	}
+	return nil
+}
+
+const instrumentationScope = "github.com/qdm12/goshutdown"
+
+// spanKindInternal is the OTLP span kind for internal operations.
+const spanKindInternal = 1
+
+type exportRequest struct {
+	ResourceSpans []resourceSpans `json:"resourceSpans"`
+}
+
+type resourceSpans struct {
+	Resource   resource     `json:"resource"`
+	ScopeSpans []scopeSpans `json:"scopeSpans"`
+}
+
+type resource struct {
+	Attributes []keyValue `json:"attributes"`
+}
+
+type scopeSpans struct {
+	Scope scope      `json:"scope"`
+	Spans []jsonSpan `json:"spans"`
+}
+
+type scope struct {
+	Name string `json:"name"`
+}
+
+type jsonSpan struct {
+	TraceID           string     `json:"traceId"`
+	SpanID            string     `json:"spanId"`
+	ParentSpanID      string     `json:"parentSpanId,omitempty"`
+	Name              string     `json:"name"`
+	Kind              int        `json:"kind"`
+	StartTimeUnixNano string     `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string     `json:"endTimeUnixNano"`
+	Attributes        []keyValue `json:"attributes,omitempty"`
+	Status            status     `json:"status"`
+}
+
+type status struct {
+	Code    StatusCode `json:"code,omitempty"`
+	Message string     `json:"message,omitempty"`
+}
+
+type keyValue struct {
+	Key   string   `json:"key"`
+	Value anyValue `json:"value"`
+}
+
+type anyValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+	IntValue    *string  `json:"intValue,omitempty"`
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+}
+
+func newExportRequest(serviceName string, spans []SpanData) exportRequest {
+	jsonSpans := make([]jsonSpan, len(spans))
+	for i, span := range spans {
+		jsonSpans[i] = newJSONSpan(span)
+	}
+
+	return exportRequest{
+		ResourceSpans: []resourceSpans{{
+			Resource: resource{
+				Attributes: []keyValue{newKeyValue(tracing.Attribute{
+					Key: "service.name", Value: serviceName,
+				})},
+			},
+			ScopeSpans: []scopeSpans{{
+				Scope: scope{Name: instrumentationScope},
+				Spans: jsonSpans,
+			}},
+		}},
+	}
+}
+
+func newJSONSpan(span SpanData) jsonSpan {
+	s := jsonSpan{
+		TraceID:           span.TraceID.String(),
+		SpanID:            span.SpanID.String(),
+		Name:              span.Name,
+		Kind:              spanKindInternal,
+		StartTimeUnixNano: strconv.FormatInt(span.Start.UnixNano(), 10),
+		EndTimeUnixNano:   strconv.FormatInt(span.End.UnixNano(), 10),
+		Status: status{
+			Code:    span.StatusCode,
+			Message: span.StatusMessage,
+		},
+	}
+	if span.ParentSpanID.IsValid() {
+		s.ParentSpanID = span.ParentSpanID.String()
+	}
+	for _, attribute := range span.Attributes {
+		s.Attributes = append(s.Attributes, newKeyValue(attribute))
+	}
+	return s
+}
+
+func newKeyValue(attribute tracing.Attribute) keyValue {
+	kv := keyValue{Key: attribute.Key}
+	switch value := attribute.Value.(type) {
+	case string:
+		kv.Value.StringValue = &value
+	case bool:
+		kv.Value.BoolValue = &value
+	case int:
+		s := strconv.Itoa(value)
+		kv.Value.IntValue = &s
+	case int64:
+		s := strconv.FormatInt(value, 10)
+		kv.Value.IntValue = &s
+	case float64:
+		kv.Value.DoubleValue = &value
+	default:
+		s := fmt.Sprint(value)
+		kv.Value.StringValue = &s
+	}
+	return kv
+}
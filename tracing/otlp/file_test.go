@@ -0,0 +1,81 @@
+package otlp
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/qdm12/goshutdown/tracing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_File_Export(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "traces.json")
+	exporter := NewFile(path, "app")
+
+	traceID := TraceID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
+	rootSpan := SpanData{
+		TraceID:    traceID,
+		SpanID:     SpanID{1, 1, 1, 1, 1, 1, 1, 1},
+		Name:       "root",
+		Start:      time.Unix(1, 0),
+		End:        time.Unix(2, 0),
+		StatusCode: StatusCodeOK,
+		Attributes: []tracing.Attribute{
+			{Key: tracing.KeyPath, Value: "root"},
+			{Key: tracing.KeyCritical, Value: false},
+		},
+	}
+	childSpan := SpanData{
+		TraceID:       traceID,
+		SpanID:        SpanID{2, 2, 2, 2, 2, 2, 2, 2},
+		ParentSpanID:  rootSpan.SpanID,
+		Name:          "child",
+		Start:         time.Unix(1, 5),
+		End:           time.Unix(1, 10),
+		StatusCode:    StatusCodeError,
+		StatusMessage: errors.New("test error").Error(),
+		Attributes: []tracing.Attribute{
+			{Key: "count", Value: 3},
+		},
+	}
+
+	err := exporter.Export([]SpanData{childSpan})
+	require.NoError(t, err)
+	err = exporter.Export([]SpanData{rootSpan})
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	const expected = `{"resourceSpans":[{"resource":{"attributes":[{"key":"service.name","value":{"stringValue":"app"}}]},` +
+		`"scopeSpans":[{"scope":{"name":"github.com/qdm12/goshutdown"},"spans":[` +
+		`{"traceId":"0102030405060708090a0b0c0d0e0f10","spanId":"0202020202020202",` +
+		`"parentSpanId":"0101010101010101","name":"child","kind":1,` +
+		`"startTimeUnixNano":"1000000005","endTimeUnixNano":"1000000010",` +
+		`"attributes":[{"key":"count","value":{"intValue":"3"}}],` +
+		`"status":{"code":2,"message":"test error"}}]}]}]}` + "\n" +
+		`{"resourceSpans":[{"resource":{"attributes":[{"key":"service.name","value":{"stringValue":"app"}}]},` +
+		`"scopeSpans":[{"scope":{"name":"github.com/qdm12/goshutdown"},"spans":[` +
+		`{"traceId":"0102030405060708090a0b0c0d0e0f10","spanId":"0101010101010101",` +
+		`"name":"root","kind":1,` +
+		`"startTimeUnixNano":"1000000000","endTimeUnixNano":"2000000000",` +
+		`"attributes":[{"key":"goshutdown.path","value":{"stringValue":"root"}},` +
+		`{"key":"goshutdown.critical","value":{"boolValue":false}}],` +
+		`"status":{"code":1}}]}]}]}` + "\n"
+	assert.Equal(t, expected, string(data))
+
+	t.Run("missing directory", func(t *testing.T) {
+		t.Parallel()
+
+		exporter := NewFile(filepath.Join(t.TempDir(), "missing", "traces.json"), "app")
+
+		err := exporter.Export([]SpanData{rootSpan})
+		require.Error(t, err)
+	})
+}
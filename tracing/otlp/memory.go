@@ -0,0 +1,29 @@
+package otlp
+
+import "sync"
+
+// Memory is an exporter keeping the spans exported in memory.
+type Memory struct {
+	mutex sync.Mutex
+	spans []SpanData
+}
+
+// NewMemory creates an in-memory exporter.
+func NewMemory() *Memory {
+	return &Memory{}
+}
+
+// Export adds the spans given to the spans kept in memory.
+func (m *Memory) Export(spans []SpanData) (err error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.spans = append(m.spans, spans...)
+	return nil
+}
+
+// Spans returns a copy of the spans exported, in the order they ended.
+func (m *Memory) Spans() (spans []SpanData) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return append([]SpanData(nil), m.spans...)
+}
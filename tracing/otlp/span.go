@@ -0,0 +1,55 @@
+package otlp
+
+import (
+	"encoding/hex"
+	"time"
+
+	"github.com/qdm12/goshutdown/tracing"
+)
+
+// TraceID is the identifier of a trace.
+type TraceID [16]byte
+
+func (id TraceID) String() string {
+	return hex.EncodeToString(id[:])
+}
+
+// SpanID is the identifier of a span.
+type SpanID [8]byte
+
+func (id SpanID) String() string {
+	return hex.EncodeToString(id[:])
+}
+
+// IsValid returns true if the span identifier is not all zeros.
+func (id SpanID) IsValid() bool {
+	return id != SpanID{}
+}
+
+// StatusCode is the status code of a span,
+// with the values of the OTLP protocol.
+type StatusCode int
+
+const (
+	// StatusCodeUnset is the status code for a span not ended.
+	StatusCodeUnset StatusCode = 0
+	// StatusCodeOK is the status code for a span ended without error.
+	StatusCodeOK StatusCode = 1
+	// StatusCodeError is the status code for a span ended with an error.
+	StatusCodeError StatusCode = 2
+)
+
+// SpanData is the data of an ended span.
+type SpanData struct {
+	TraceID TraceID
+	SpanID  SpanID
+	// ParentSpanID is the identifier of the parent span,
+	// and is all zeros for a root span.
+	ParentSpanID  SpanID
+	Name          string
+	Start         time.Time
+	End           time.Time
+	Attributes    []tracing.Attribute
+	StatusCode    StatusCode
+	StatusMessage string
+}
@@ -0,0 +1,109 @@
+// Package otlp defines a tracing.Tracer recording spans in the
+// OpenTelemetry data model, and exporters keeping them in memory or
+// writing them to a file in the OTLP JSON format, for local testing.
+package otlp
+
+import (
+	"context"
+	"crypto/rand"
+	"sync"
+	"time"
+
+	"github.com/qdm12/goshutdown/tracing"
+)
+
+// Exporter exports ended spans.
+type Exporter interface {
+	Export(spans []SpanData) (err error)
+}
+
+// Tracer is a tracing.Tracer keeping the spans ended until they
+// are exported with Flush.
+type Tracer struct {
+	exporter Exporter
+	mutex    sync.Mutex
+	ended    []SpanData
+}
+
+// New creates a tracer exporting its spans with the exporter given.
+func New(exporter Exporter) *Tracer {
+	return &Tracer{
+		exporter: exporter,
+	}
+}
+
+type contextKey struct{}
+
+// Start starts a span in a new trace, or in the trace of the
+// span contained in ctx if any, as a child of this span.
+func (t *Tracer) Start(ctx context.Context, name string, start time.Time,
+	attributes ...tracing.Attribute) (spanCtx context.Context, span tracing.Span) {
+	data := SpanData{
+		Name:       name,
+		SpanID:     newSpanID(),
+		Start:      start,
+		Attributes: append([]tracing.Attribute(nil), attributes...),
+	}
+
+	parent, ok := ctx.Value(contextKey{}).(*otlpSpan)
+	if ok {
+		data.TraceID = parent.data.TraceID
+		data.ParentSpanID = parent.data.SpanID
+	} else {
+		data.TraceID = newTraceID()
+	}
+
+	s := &otlpSpan{
+		tracer: t,
+		data:   data,
+	}
+	return context.WithValue(ctx, contextKey{}, s), s
+}
+
+// Flush exports the spans ended since the last flush, if any.
+// It should be called once the shutdown is done.
+func (t *Tracer) Flush() (err error) {
+	t.mutex.Lock()
+	ended := t.ended
+	t.ended = nil
+	t.mutex.Unlock()
+
+	if len(ended) == 0 {
+		return nil
+	}
+	return t.exporter.Export(ended)
+}
+
+func (t *Tracer) addEnded(data SpanData) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.ended = append(t.ended, data)
+}
+
+type otlpSpan struct {
+	tracer *Tracer
+	data   SpanData
+}
+
+func (s *otlpSpan) End(end time.Time, err error, attributes ...tracing.Attribute) {
+	data := s.data
+	data.End = end
+	data.Attributes = append(data.Attributes, attributes...)
+	if err != nil {
+		data.StatusCode = StatusCodeError
+		data.StatusMessage = err.Error()
+	} else {
+		data.StatusCode = StatusCodeOK
+	}
+	s.tracer.addEnded(data)
+}
+
+func newTraceID() (id TraceID) {
+	_, _ = rand.Read(id[:])
+	return id
+}
+
+func newSpanID() (id SpanID) {
+	_, _ = rand.Read(id[:])
+	return id
+}
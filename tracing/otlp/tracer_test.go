@@ -0,0 +1,135 @@
+package otlp
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/qdm12/goshutdown/goroutine"
+	"github.com/qdm12/goshutdown/group"
+	"github.com/qdm12/goshutdown/order"
+	"github.com/qdm12/goshutdown/tracing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Tracer(t *testing.T) {
+	t.Parallel()
+
+	errTest := errors.New("test error")
+	start := time.Unix(1, 0)
+	end := time.Unix(2, 0)
+
+	exporter := NewMemory()
+	tracer := New(exporter)
+
+	rootCtx, root := tracer.Start(context.Background(), "root", start,
+		tracing.Attribute{Key: "key", Value: "value"})
+	_, child := tracer.Start(rootCtx, "child", start)
+	child.End(end, errTest)
+	root.End(end, nil, tracing.Attribute{Key: "status", Value: "success"})
+
+	assert.Empty(t, exporter.Spans())
+
+	err := tracer.Flush()
+	require.NoError(t, err)
+
+	spans := exporter.Spans()
+	require.Len(t, spans, 2)
+
+	childData, rootData := spans[0], spans[1]
+	assert.Equal(t, rootData.TraceID, childData.TraceID)
+	assert.Equal(t, rootData.SpanID, childData.ParentSpanID)
+	assert.False(t, rootData.ParentSpanID.IsValid())
+	assert.NotEqual(t, rootData.SpanID, childData.SpanID)
+
+	rootData.TraceID, rootData.SpanID = TraceID{}, SpanID{}
+	assert.Equal(t, SpanData{
+		Name:  "root",
+		Start: start,
+		End:   end,
+		Attributes: []tracing.Attribute{
+			{Key: "key", Value: "value"},
+			{Key: "status", Value: "success"},
+		},
+		StatusCode: StatusCodeOK,
+	}, rootData)
+
+	assert.Equal(t, "child", childData.Name)
+	assert.Equal(t, StatusCodeError, childData.StatusCode)
+	assert.Equal(t, "test error", childData.StatusMessage)
+
+	// Spans are exported only once
+	err = tracer.Flush()
+	require.NoError(t, err)
+	assert.Len(t, exporter.Spans(), 2)
+
+	t.Run("separate traces", func(t *testing.T) {
+		t.Parallel()
+
+		exporter := NewMemory()
+		tracer := New(exporter)
+
+		_, a := tracer.Start(context.Background(), "a", start)
+		_, b := tracer.Start(context.Background(), "b", start)
+		a.End(end, nil)
+		b.End(end, nil)
+
+		err := tracer.Flush()
+		require.NoError(t, err)
+
+		spans := exporter.Spans()
+		require.Len(t, spans, 2)
+		assert.NotEqual(t, spans[0].TraceID, spans[1].TraceID)
+	})
+}
+
+func Test_Tracer_shutdown(t *testing.T) {
+	t.Parallel()
+
+	exporter := NewMemory()
+	tracer := New(exporter)
+	observer := tracing.New(tracer)
+
+	handlerA, _, doneA := goroutine.New("A")
+	close(doneA)
+	handlerB, _, doneB := goroutine.New("B")
+	close(doneB)
+	handlerC, _, doneC := goroutine.New("C")
+	close(doneC)
+
+	workers := group.New("workers")
+	workers.Add(handlerA, handlerB)
+
+	root := order.New("root", observer.OrderOption())
+	root.Append(workers, handlerC)
+
+	err := root.Shutdown(context.Background())
+	require.NoError(t, err)
+
+	err = tracer.Flush()
+	require.NoError(t, err)
+
+	spans := exporter.Spans()
+	require.Len(t, spans, 5)
+
+	nameToSpan := make(map[string]SpanData, len(spans))
+	for _, span := range spans {
+		nameToSpan[span.Name] = span
+		assert.Equal(t, StatusCodeOK, span.StatusCode)
+		assert.Equal(t, spans[0].TraceID, span.TraceID)
+	}
+
+	expectedParents := map[string]string{
+		"workers": "root",
+		"A":       "workers",
+		"B":       "workers",
+		"C":       "root",
+	}
+	for name, parentName := range expectedParents {
+		assert.Equal(t, nameToSpan[parentName].SpanID, nameToSpan[name].ParentSpanID,
+			"parent span of %s", name)
+	}
+	assert.False(t, nameToSpan["root"].ParentSpanID.IsValid())
+}
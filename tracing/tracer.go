@@ -0,0 +1,44 @@
+// Package tracing defines a small tracer interface, and a shutdown events
+// observer producing a span for each handler shutdown, with spans nested
+// as the handlers are in the tree.
+package tracing
+
+import (
+	"context"
+	"time"
+)
+
+// Tracer starts spans. It can be implemented by an adapter to
+// a tracing library, such as the one of the otlp subpackage.
+type Tracer interface {
+	// Start starts a span with the name, start time and attributes given.
+	// The span is a child of the span contained in ctx if any, and the
+	// context returned contains the span started.
+	Start(ctx context.Context, name string, start time.Time,
+		attributes ...Attribute) (spanCtx context.Context, span Span)
+}
+
+// Span is a span started by a Tracer.
+type Span interface {
+	// End ends the span at the end time given, adding the attributes
+	// given to the span. The span status is set to an error status
+	// with the error message if err is not nil.
+	End(end time.Time, err error, attributes ...Attribute)
+}
+
+// Attribute is a span attribute.
+type Attribute struct {
+	Key string
+	// Value is a string or a bool for the attributes set by the Observer.
+	Value interface{}
+}
+
+// Attribute keys set by the Observer.
+const (
+	// KeyPath is the key for the path of the handler in the tree.
+	KeyPath = "goshutdown.path"
+	// KeyCritical is the key for the critical flag of the handler.
+	KeyCritical = "goshutdown.critical"
+	// KeyStatus is the key for the shutdown status of the handler.
+	KeyStatus = "goshutdown.status"
+)
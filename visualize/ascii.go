@@ -0,0 +1,46 @@
+package visualize
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/qdm12/goshutdown/handler"
+)
+
+// ASCII renders the tree of the handler given as an indented ASCII tree,
+// for example to log it at startup. Children of an order are numbered in
+// the order they are shut down, and children of a group are not numbered
+// since they are shut down in parallel.
+func ASCII(h handler.Handler) string {
+	return Tree(h).ASCII()
+}
+
+// ASCII renders the tree of the node as an indented ASCII tree.
+func (n *Node) ASCII() string {
+	builder := new(strings.Builder)
+	writeASCII(builder, n, "", "")
+	return builder.String()
+}
+
+var newlineReplacer = strings.NewReplacer("\r\n", " ", "\n", " ") //nolint:gochecknoglobals
+
+func writeASCII(builder *strings.Builder, node *Node, linePrefix, childPrefix string) {
+	builder.WriteString(linePrefix)
+	builder.WriteString(newlineReplacer.Replace(node.Name))
+	details := node.details()
+	if len(details) > 0 {
+		builder.WriteString(" (" + strings.Join(details, ", ") + ")")
+	}
+	builder.WriteString("\n")
+
+	for i, child := range node.Children {
+		branch, indent := "├── ", "│   "
+		if i == len(node.Children)-1 {
+			branch, indent = "└── ", "    "
+		}
+		if node.sequential() {
+			branch += strconv.Itoa(i+1) + ". "
+		}
+		writeASCII(builder, child, childPrefix+branch, childPrefix+indent)
+	}
+}
@@ -0,0 +1,61 @@
+package visualize
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/qdm12/goshutdown/handler"
+)
+
+// DOT renders the tree of the handler given as a Graphviz DOT graph.
+// Edges from an order to its children are labeled with the position of
+// the child in the shutdown order, and critical handlers are outlined in red.
+func DOT(h handler.Handler) string {
+	return Tree(h).DOT()
+}
+
+// DOT renders the tree of the node as a Graphviz DOT graph.
+func (n *Node) DOT() string {
+	builder := new(strings.Builder)
+	builder.WriteString("digraph shutdown {\n")
+	builder.WriteString("  node [shape=box];\n")
+
+	var edges []string
+	n.walk(func(node *Node, index int, parentEdge *edge) {
+		lines := labelLines(node)
+		for i, line := range lines {
+			lines[i] = dotEscape(line)
+		}
+		attributes := fmt.Sprintf(`label="%s"`, strings.Join(lines, `\n`))
+		if node.Critical {
+			attributes += ", color=red, penwidth=2"
+		}
+		fmt.Fprintf(builder, "  n%d [%s];\n", index, attributes)
+
+		if parentEdge == nil {
+			return
+		}
+		line := fmt.Sprintf("  n%d -> n%d", parentEdge.parentIndex, index)
+		if parentEdge.sequential {
+			line += fmt.Sprintf(` [label="%d"]`, parentEdge.position)
+		}
+		edges = append(edges, line+";\n")
+	})
+
+	for _, edge := range edges {
+		builder.WriteString(edge)
+	}
+	builder.WriteString("}\n")
+	return builder.String()
+}
+
+var dotReplacer = strings.NewReplacer( //nolint:gochecknoglobals
+	`\`, `\\`,
+	`"`, `\"`,
+	"\r\n", " ",
+	"\n", " ",
+)
+
+func dotEscape(s string) string {
+	return dotReplacer.Replace(s)
+}
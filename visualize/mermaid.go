@@ -0,0 +1,66 @@
+package visualize
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/qdm12/goshutdown/handler"
+)
+
+// Mermaid renders the tree of the handler given as a Mermaid flowchart.
+// Edges from an order to its children are labeled with the position of
+// the child in the shutdown order, and critical handlers are outlined in red.
+func Mermaid(h handler.Handler) string {
+	return Tree(h).Mermaid()
+}
+
+// Mermaid renders the tree of the node as a Mermaid flowchart.
+func (n *Node) Mermaid() string {
+	builder := new(strings.Builder)
+	builder.WriteString("flowchart TD\n")
+
+	var edges []string
+	var criticalNodes []string
+	n.walk(func(node *Node, index int, parentEdge *edge) {
+		lines := labelLines(node)
+		for i, line := range lines {
+			lines[i] = mermaidEscape(line)
+		}
+		fmt.Fprintf(builder, "  n%d[\"%s\"]\n", index, strings.Join(lines, "<br/>"))
+		if node.Critical {
+			criticalNodes = append(criticalNodes, fmt.Sprintf("n%d", index))
+		}
+
+		if parentEdge == nil {
+			return
+		}
+		arrow := "-->"
+		if parentEdge.sequential {
+			arrow += fmt.Sprintf("|%d|", parentEdge.position)
+		}
+		edges = append(edges, fmt.Sprintf("  n%d %s n%d\n",
+			parentEdge.parentIndex, arrow, index))
+	})
+
+	for _, edge := range edges {
+		builder.WriteString(edge)
+	}
+
+	if len(criticalNodes) > 0 {
+		builder.WriteString("  classDef critical stroke:#d00,stroke-width:2px\n")
+		builder.WriteString("  class " + strings.Join(criticalNodes, ",") + " critical\n")
+	}
+	return builder.String()
+}
+
+var mermaidReplacer = strings.NewReplacer( //nolint:gochecknoglobals
+	`"`, "#quot;",
+	"<", "#lt;",
+	">", "#gt;",
+	"\r\n", " ",
+	"\n", " ",
+)
+
+func mermaidEscape(s string) string {
+	return mermaidReplacer.Replace(s)
+}
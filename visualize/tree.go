@@ -0,0 +1,145 @@
+// Package visualize renders a tree of shutdown handlers as a Graphviz DOT
+// graph, a Mermaid flowchart or an indented ASCII tree, to review what is
+// shut down and when.
+package visualize
+
+import (
+	"time"
+
+	"github.com/qdm12/goshutdown/graph"
+	"github.com/qdm12/goshutdown/group"
+	"github.com/qdm12/goshutdown/handler"
+	"github.com/qdm12/goshutdown/order"
+	"github.com/qdm12/goshutdown/report"
+	"github.com/qdm12/goshutdown/supervisor"
+)
+
+// Node is a node of the tree of shutdown handlers.
+type Node struct {
+	// Name is the name of the handler.
+	Name string
+	// Kind is KindOrder for an order handler or an order lifecycle,
+	// KindGroup for a group handler, a group lifecycle or a supervisor,
+	// KindGraph for a graph handler and KindHandler for any other handler.
+	Kind report.Kind
+	// Critical is true if the handler is critical.
+	Critical bool
+	// Timeout is the shutdown timeout of the handler, and is 0 if it
+	// has no timeout or if it does not implement handler.Timeouter.
+	Timeout time.Duration
+	// Children are the nodes of the children handlers, for handlers
+	// implementing handler.Parent. Children of an order are in the
+	// order they are shut down, and children of a graph are in a
+	// topological shutdown order.
+	Children []*Node
+}
+
+// Tree returns the tree of nodes for the handler given and its children.
+func Tree(h handler.Handler) *Node {
+	node := &Node{
+		Name:     h.Name(),
+		Kind:     kindOf(h),
+		Critical: h.IsCritical(),
+	}
+
+	timeouter, ok := h.(handler.Timeouter)
+	if ok {
+		node.Timeout = timeouter.Timeout()
+	}
+
+	parent, ok := h.(handler.Parent)
+	if ok {
+		children := parent.Children()
+		node.Children = make([]*Node, len(children))
+		for i, child := range children {
+			node.Children[i] = Tree(child)
+		}
+	}
+
+	return node
+}
+
+// kinder is implemented by handlers knowing their kind,
+// such as a lifecycle.Lifecycle.
+type kinder interface {
+	Kind() report.Kind
+}
+
+func kindOf(h handler.Handler) report.Kind {
+	switch h := h.(type) {
+	case kinder:
+		return h.Kind()
+	case order.Handler:
+		return report.KindOrder
+	case group.Handler, supervisor.Handler:
+		return report.KindGroup
+	case graph.Handler:
+		return report.KindGraph
+	default:
+		return report.KindHandler
+	}
+}
+
+// sequential returns true if the children of the node are
+// shut down one after the other.
+func (n *Node) sequential() bool {
+	return n.Kind == report.KindOrder
+}
+
+// details returns the details of the node, such as its kind,
+// its timeout and its critical marker.
+func (n *Node) details() (details []string) {
+	switch n.Kind {
+	case report.KindOrder:
+		details = append(details, "order, sequential")
+	case report.KindGroup:
+		details = append(details, "group, parallel")
+	case report.KindGraph:
+		details = append(details, "graph, dependency order")
+	}
+	if n.Timeout > 0 {
+		details = append(details, "timeout "+n.Timeout.String())
+	}
+	if n.Critical {
+		details = append(details, "critical")
+	}
+	return details
+}
+
+// edge is the edge from a parent node to one of its children.
+type edge struct {
+	// parentIndex is the index of the parent node.
+	parentIndex int
+	// position is the position of the child in the children
+	// of the parent, starting from 1.
+	position int
+	// sequential is true if the parent shuts down its children
+	// one after the other.
+	sequential bool
+}
+
+// walk calls fn for each node of the tree in depth first order,
+// with the index of the node in this order and the edge from
+// its parent node, which is nil for the root node.
+func (n *Node) walk(fn func(node *Node, index int, parentEdge *edge)) {
+	index := 0
+	var visit func(node *Node, parentEdge *edge)
+	visit = func(node *Node, parentEdge *edge) {
+		nodeIndex := index
+		index++
+		fn(node, nodeIndex, parentEdge)
+		for i, child := range node.Children {
+			visit(child, &edge{
+				parentIndex: nodeIndex,
+				position:    i + 1,
+				sequential:  node.sequential(),
+			})
+		}
+	}
+	visit(n, nil)
+}
+
+func labelLines(node *Node) (lines []string) {
+	lines = append(lines, node.Name)
+	return append(lines, node.details()...)
+}
@@ -0,0 +1,267 @@
+package visualize
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/qdm12/goshutdown/drain"
+	"github.com/qdm12/goshutdown/goroutine"
+	"github.com/qdm12/goshutdown/graph"
+	"github.com/qdm12/goshutdown/group"
+	"github.com/qdm12/goshutdown/handler"
+	"github.com/qdm12/goshutdown/handler/mock_handler"
+	"github.com/qdm12/goshutdown/lifecycle"
+	"github.com/qdm12/goshutdown/order"
+	"github.com/qdm12/goshutdown/report"
+	"github.com/qdm12/goshutdown/supervisor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestHandler returns the handler tree:
+// a reverse order "root" with a timeout of 5s, shutting down
+// a critical group "workers" without timeout, with goroutines
+// "worker \"1\"" with a timeout of 100ms and "worker 2" with the
+// default timeout, and then a "database" handler without timeout.
+func newTestHandler(ctrl *gomock.Controller) handler.Handler {
+	database := mock_handler.NewMockHandler(ctrl)
+	database.EXPECT().Name().Return("database").AnyTimes()
+	database.EXPECT().IsCritical().Return(false).AnyTimes()
+
+	worker1, _, _ := goroutine.New(`worker "1"`,
+		goroutine.OptionTimeout(100*time.Millisecond))
+	worker2, _, _ := goroutine.New("worker 2")
+
+	workers := group.New("workers", group.OptionCritical(), group.OptionTimeout(0))
+	workers.Add(worker1, worker2)
+
+	root := order.NewReverse("root", order.OptionTimeout(5*time.Second))
+	root.Append(database, workers)
+	return root
+}
+
+func Test_Tree(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	node := Tree(newTestHandler(ctrl))
+
+	expected := &Node{
+		Name:    "root",
+		Kind:    report.KindOrder,
+		Timeout: 5 * time.Second,
+		Children: []*Node{
+			{
+				Name:     "workers",
+				Kind:     report.KindGroup,
+				Critical: true,
+				Children: []*Node{
+					{
+						Name:    `worker "1"`,
+						Kind:    report.KindHandler,
+						Timeout: 100 * time.Millisecond,
+					},
+					{
+						Name:    "worker 2",
+						Kind:    report.KindHandler,
+						Timeout: time.Second,
+					},
+				},
+			},
+			{
+				Name: "database",
+				Kind: report.KindHandler,
+			},
+		},
+	}
+	assert.Equal(t, expected, node)
+}
+
+func Test_Tree_graph(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	newHandler := func(name string) *mock_handler.MockHandler {
+		h := mock_handler.NewMockHandler(ctrl)
+		h.EXPECT().Name().Return(name).AnyTimes()
+		h.EXPECT().IsCritical().Return(name == "database").AnyTimes()
+		return h
+	}
+	api, queue, database := newHandler("api"), newHandler("queue"), newHandler("database")
+
+	g := graph.New("graph", graph.OptionTimeout(2*time.Second))
+	require.NoError(t, g.Add(database, queue))
+	require.NoError(t, g.Add(queue, api))
+
+	node := Tree(g)
+
+	expected := &Node{
+		Name:    "graph",
+		Kind:    report.KindGraph,
+		Timeout: 2 * time.Second,
+		Children: []*Node{
+			{Name: "api", Kind: report.KindHandler},
+			{Name: "queue", Kind: report.KindHandler},
+			{Name: "database", Kind: report.KindHandler, Critical: true},
+		},
+	}
+	assert.Equal(t, expected, node)
+
+	const expectedASCII = `graph (graph, dependency order, timeout 2s)
+├── api
+├── queue
+└── database (critical)
+`
+	assert.Equal(t, expectedASCII, node.ASCII())
+}
+
+func Test_Tree_lifecycle(t *testing.T) {
+	t.Parallel()
+
+	noop := func(ctx context.Context) error { return nil }
+	workers := lifecycle.NewGroup("workers", group.OptionTimeout(0))
+	workers.Add(lifecycle.Go("worker", func(ctx context.Context) error {
+		<-ctx.Done()
+		return nil
+	}, goroutine.OptionCritical()))
+
+	root := lifecycle.NewOrder("root", order.OptionTimeout(5*time.Second))
+	root.Add(lifecycle.NewFunc("database", noop, noop), workers)
+
+	err := root.Start(context.Background())
+	require.NoError(t, err)
+	defer func() {
+		err := root.Stop(context.Background())
+		assert.NoError(t, err)
+	}()
+
+	node := Tree(root)
+
+	expected := &Node{
+		Name:    "root",
+		Kind:    report.KindOrder,
+		Timeout: 5 * time.Second,
+		Children: []*Node{
+			{
+				Name: "workers",
+				Kind: report.KindGroup,
+				Children: []*Node{
+					{Name: "worker", Kind: report.KindHandler, Critical: true},
+				},
+			},
+			{Name: "database", Kind: report.KindHandler},
+		},
+	}
+	assert.Equal(t, expected, node)
+}
+
+func Test_Tree_drain(t *testing.T) {
+	t.Parallel()
+
+	s := supervisor.New("supervisor", supervisor.OptionCritical())
+	s.Add("child", func(ctx context.Context) error {
+		<-ctx.Done()
+		return nil
+	})
+	s.Start()
+	defer func() {
+		err := s.Shutdown(context.Background())
+		assert.NoError(t, err)
+	}()
+
+	d := drain.New("drain", s, drain.NewReadiness())
+
+	node := Tree(d)
+
+	expected := &Node{
+		Name: "drain",
+		Kind: report.KindHandler,
+		Children: []*Node{
+			{
+				Name:     "supervisor",
+				Kind:     report.KindGroup,
+				Critical: true,
+				Timeout:  time.Second,
+				Children: []*Node{
+					{Name: "child", Kind: report.KindHandler, Timeout: time.Second},
+				},
+			},
+		},
+	}
+	assert.Equal(t, expected, node)
+}
+
+func Test_ASCII(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	s := ASCII(newTestHandler(ctrl))
+
+	const expected = `root (order, sequential, timeout 5s)
+├── 1. workers (group, parallel, critical)
+│   ├── worker "1" (timeout 100ms)
+│   └── worker 2 (timeout 1s)
+└── 2. database
+`
+	assert.Equal(t, expected, s)
+}
+
+func Test_DOT(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	s := DOT(newTestHandler(ctrl))
+
+	const expected = `digraph shutdown {
+  node [shape=box];
+  n0 [label="root\norder, sequential\ntimeout 5s"];
+  n1 [label="workers\ngroup, parallel\ncritical", color=red, penwidth=2];
+  n2 [label="worker \"1\"\ntimeout 100ms"];
+  n3 [label="worker 2\ntimeout 1s"];
+  n4 [label="database"];
+  n0 -> n1 [label="1"];
+  n1 -> n2;
+  n1 -> n3;
+  n0 -> n4 [label="2"];
+}
+`
+	assert.Equal(t, expected, s)
+}
+
+func Test_Mermaid(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	s := Mermaid(newTestHandler(ctrl))
+
+	const expected = `flowchart TD
+  n0["root<br/>order, sequential<br/>timeout 5s"]
+  n1["workers<br/>group, parallel<br/>critical"]
+  n2["worker #quot;1#quot;<br/>timeout 100ms"]
+  n3["worker 2<br/>timeout 1s"]
+  n4["database"]
+  n0 -->|1| n1
+  n1 --> n2
+  n1 --> n3
+  n0 -->|2| n4
+  classDef critical stroke:#d00,stroke-width:2px
+  class n1 critical
+`
+	assert.Equal(t, expected, s)
+}
+
+func Test_Node_ASCII_single(t *testing.T) {
+	t.Parallel()
+
+	node := &Node{
+		Name:     "multi\nline",
+		Kind:     report.KindHandler,
+		Critical: true,
+	}
+
+	s := node.ASCII()
+
+	assert.Equal(t, "multi line (critical)\n", s)
+}